@@ -76,6 +76,10 @@ func main() {
 
 		page.GET("/", context.Handle(controller.SearchBox))
 		page.POST("/hero/skins", context.Handle(controller.GetHeroSkins))
+		// 皮肤图鉴
+		page.POST("/skin/catalog", context.Handle(controller.GetSkinCatalog))
+		// 最新皮肤
+		page.POST("/skin/latest", context.Handle(controller.GetLatestSkins))
 
 		page.GET("/search", context.Handle(controller.SearchBox))
 		page.POST("/query", context.Handle(controller.Query))
@@ -85,6 +89,7 @@ func main() {
 
 		page.POST("/version/list", context.Handle(controller.VersionList))
 		page.POST("/version/detail", context.Handle(controller.VersionDetail))
+		page.POST("/version/impact", context.Handle(controller.VersionImpact))
 
 		page.GET("/version", context.Handle(controller.QueryVersion))
 		page.POST("/version", context.Handle(controller.QueryVersion))
@@ -93,6 +98,50 @@ func main() {
 
 		// 查询当前装备的合成路线和可合成路线
 		page.POST("/equip/roadmap", context.Handle(controller.GetRoadmap))
+		// 根据已有装备和金币查询可以合成的装备
+		page.POST("/equip/finish", context.Handle(controller.EquipFinish))
+		// 汇总一套出装的属性和价格，并校验出装规则
+		page.POST("/equip/build", context.Handle(controller.EquipBuild))
+		// LOLM装备按基础、进阶、传说、附魔分组
+		page.POST("/equip/lolm", context.Handle(controller.GetLOLMEquips))
+		// 装备、英雄、符文、召唤师技能的横向对比
+		page.POST("/compare", context.Handle(controller.Compare))
+		// 同一个英雄、装备、符文、召唤师技能在另一个平台上的对应
+		page.POST("/platform/other", context.Handle(controller.GetOtherPlatform))
+		// 同一个实体在LOL和LOLM上的对比
+		page.POST("/platform/compare", context.Handle(controller.ComparePlatforms))
+		// 英雄1~18级的属性
+		page.POST("/hero/stats", context.Handle(controller.HeroStats))
+		// 根据英雄、等级、出装和符文计算攻防属性和有效生命值
+		page.POST("/hero/damage", context.Handle(controller.DamageCalc))
+		// 英雄在某个位置的优势/劣势对位
+		page.POST("/hero/matchup", context.Handle(controller.GetHeroMatchup))
+		// 克制某个英雄的英雄
+		page.POST("/hero/counter", context.Handle(controller.GetHeroCounter))
+		// 英雄推荐的完整符文页
+		page.POST("/hero/rune/pages", context.Handle(controller.GetHeroRunePages))
+		// 符文树：系、槽位、符文和属性碎片
+		page.POST("/rune/tree", context.Handle(controller.GetRuneTree))
+		// 校验符文页是否合法
+		page.POST("/rune/page/check", context.Handle(controller.CheckRunePage))
+		// 英雄不同对局时长的胜率曲线
+		page.POST("/hero/winrate/time", context.Handle(controller.GetHeroTimeWinrate))
+		// 英雄的最佳队友
+		page.POST("/hero/partners", context.Handle(controller.GetHeroPartners))
+		// 两个英雄作为队友的组合评分
+		page.POST("/team/pair", context.Handle(controller.GetPairScore))
+		// 英雄梯队
+		page.POST("/hero/tier", context.Handle(controller.TierList))
+		// 英雄胜率、出场率、禁用率的历史趋势
+		page.POST("/hero/trend", context.Handle(controller.GetHeroTrend))
+		// 近期胜率等指标涨跌最多的英雄
+		page.POST("/hero/movers", context.Handle(controller.GetHeroMovers))
+		// 本周免费英雄，附带出装
+		page.POST("/hero/free", context.Handle(controller.GetWeekFree))
+		// 英雄最近一次免费的时间
+		page.POST("/hero/free/last", context.Handle(controller.GetHeroLastFree))
+		// 周免日历
+		page.POST("/hero/free/calendar", context.Handle(controller.GetRotationCalendar))
 		// 通过heroID来查询适配的装备，这个接口是将format数据写入db，接口返回的是第三方数据源的数据
 		page.POST("/equip/suit", context.Handle(controller.SuitEquip))
 		// 页面上通过heroID来查询适配的装备
@@ -104,6 +153,8 @@ func main() {
 		page.POST("/rune/hero/suit", context.Handle(controller.GetRuneHeroSuit))
 		// 通过召唤师技能id查看适配英雄
 		page.POST("/skill/hero/suit", context.Handle(controller.GetSkillHeroSuit))
+		// 召唤师技能，按模式、解锁等级过滤
+		page.POST("/skill/list", context.Handle(controller.GetSkills))
 
 	}
 
@@ -120,6 +171,10 @@ func main() {
 		inner.POST("/index/build", context.Handle(controller.Build))
 		inner.POST("/alias/heroes", context.Handle(controller.AliasHeroes))
 		inner.POST("/alias/equip", context.Handle(controller.AliasEquip))
+		// 按名称自动匹配LOL与LOLM的实体，手动指定的保持不变
+		inner.POST("/platform/mapping/suggest", context.Handle(controller.SuggestPlatformMapping))
+		// 手动指定LOL与LOLM实体的对应关系
+		inner.POST("/platform/mapping/override", context.Handle(controller.OverridePlatformMapping))
 
 		// LOLM将英雄适合的位置写入heroes_position（批量执行）
 		inner.POST("/heroes/position", context.Handle(controller.HeroesPosition))
@@ -134,6 +189,8 @@ func main() {
 		// 装备、符文、技能适配英雄列表，汇总db然后写入redis
 		// 页面查询：1.根据装备id获取适配的英雄
 		inner.POST("/suit/high_rate/cache", context.Handle(controller.SuitHeroData2Redis))
+		// 缓存各代的key数量和命中情况
+		inner.GET("/cache/stats", context.Handle(controller.CacheStats))
 
 		// 缓存heroes的attribute
 		//inner.POST("/attr/hero/cache", context.Handle(controller.AttrData2Redis))
@@ -0,0 +1,7 @@
+-- hero_attribute 增加攻击力成长，英雄属性计算器和伤害计算使用
+ALTER TABLE hero_attribute
+    ADD COLUMN attackdamageperlevel varchar(32) NOT NULL DEFAULT '' AFTER attackdamage;
+
+-- 回填：已有的行 attackdamageperlevel 为空，攻击力没有成长。
+-- 上线后对两个平台各调用一次 POST /heroes/attr {"hero_id": "0", "platform": 0|1} 重新拉取当前版本的属性；
+-- 上游只提供当前版本，历史版本的行无法回填，计算结果的攻击力保持为1级的值
@@ -0,0 +1,11 @@
+-- hero_skin 增加炫彩、系列和上线时间，皮肤图鉴和ES皮肤索引使用
+ALTER TABLE hero_skin
+    ADD COLUMN chromaImg       varchar(255) NOT NULL DEFAULT '' AFTER sourceImg,
+    ADD COLUMN chromas         varchar(8)   NOT NULL DEFAULT '' COMMENT '1:有炫彩' AFTER chromaImg,
+    ADD COLUMN chromasBelongId varchar(32)  NOT NULL DEFAULT '' COMMENT '炫彩所属的皮肤ID，不是炫彩时为0' AFTER chromas,
+    ADD COLUMN suitType        varchar(255) NOT NULL DEFAULT '' COMMENT '皮肤系列' AFTER chromasBelongId,
+    ADD COLUMN publishTime     varchar(32)  NOT NULL DEFAULT '' AFTER suitType;
+
+-- 回填：已有的行新字段为空，之前跳过的炫彩也没有入库。
+-- 上线后调用一次 POST /heroes/attr {"hero_id": "0", "platform": 0} 重新拉取端游英雄的皮肤，
+-- 再调用 POST /index/build {"index": "hero_skin"} 重建皮肤索引
@@ -0,0 +1,6 @@
+-- heroes_position 增加禁用率和段位，梯队榜使用
+ALTER TABLE heroes_position
+    ADD COLUMN ban_rate int NOT NULL DEFAULT 0 AFTER win_rate,
+    ADD COLUMN level int NOT NULL DEFAULT 0 AFTER ban_rate;
+
+-- 已有的行 level 为0（common.LevelDefault），即LOL查询使用的段位，不需要回填
@@ -0,0 +1,4 @@
+-- heroes_position 增加LOLM的强度评分和强度等级
+ALTER TABLE heroes_position
+    ADD COLUMN strength varchar(32) NOT NULL DEFAULT '' AFTER level,
+    ADD COLUMN strength_level varchar(32) NOT NULL DEFAULT '' AFTER strength;
@@ -0,0 +1,51 @@
+-- heroes_position、heroes_suit 的每日快照，英雄趋势和涨跌榜使用
+CREATE TABLE IF NOT EXISTS heroes_position_snapshot
+(
+    id             bigint unsigned NOT NULL AUTO_INCREMENT,
+    heroId         varchar(32)     NOT NULL DEFAULT '',
+    pos            varchar(32)     NOT NULL DEFAULT '',
+    show_rate      int             NOT NULL DEFAULT 0,
+    win_rate       int             NOT NULL DEFAULT 0,
+    ban_rate       int             NOT NULL DEFAULT 0,
+    level          int             NOT NULL DEFAULT 0,
+    strength       varchar(32)     NOT NULL DEFAULT '',
+    strength_level varchar(32)     NOT NULL DEFAULT '',
+    platform       int             NOT NULL DEFAULT 0,
+    version        varchar(32)     NOT NULL DEFAULT '',
+    statDate       varchar(16)     NOT NULL DEFAULT '' COMMENT '统计日期 2006-01-02',
+    ctime          datetime        NOT NULL DEFAULT current_timestamp(),
+    utime          datetime        NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+    PRIMARY KEY (id),
+    KEY idx_platform_level_date (platform, level, statDate),
+    KEY idx_hero_date (heroId, platform, statDate)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS heroes_suit_snapshot
+(
+    id       bigint unsigned NOT NULL AUTO_INCREMENT,
+    heroId   varchar(32)     NOT NULL DEFAULT '',
+    pos      varchar(32)     NOT NULL DEFAULT '',
+    itemids  varchar(255)    NOT NULL DEFAULT '',
+    runeids  varchar(255)    NOT NULL DEFAULT '',
+    skillids varchar(64)     NOT NULL DEFAULT '',
+    igamecnt int             NOT NULL DEFAULT 0,
+    wincnt   int             NOT NULL DEFAULT 0,
+    winrate  int             NOT NULL DEFAULT 0,
+    allcnt   int             NOT NULL DEFAULT 0,
+    showrate int             NOT NULL DEFAULT 0,
+    type     int             NOT NULL DEFAULT 0,
+    platform int             NOT NULL DEFAULT 0,
+    version  varchar(32)     NOT NULL DEFAULT '',
+    statDate varchar(16)     NOT NULL DEFAULT '' COMMENT '统计日期 2006-01-02',
+    ctime    datetime        NOT NULL DEFAULT current_timestamp(),
+    utime    datetime        NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+    PRIMARY KEY (id),
+    KEY idx_hero_date (heroId, platform, statDate)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+-- 已经建过 heroes_suit_snapshot 时补上符文和召唤师技能
+-- ALTER TABLE heroes_suit_snapshot
+--     ADD COLUMN runeids varchar(255) NOT NULL DEFAULT '' AFTER itemids,
+--     ADD COLUMN skillids varchar(64) NOT NULL DEFAULT '' AFTER runeids;
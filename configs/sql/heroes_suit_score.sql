@@ -0,0 +1,6 @@
+-- heroes_suit 增加胜率的置信下界，推荐出装和装备适配英雄按它排序、筛选
+ALTER TABLE heroes_suit
+    ADD COLUMN score int NOT NULL DEFAULT 0 COMMENT '胜率的Wilson下界，万分比' AFTER showrate;
+
+-- 已有的行 score 为0，下一次全量同步出装（BatchUpdateSuitEquip）后重新计算。
+-- 在此之前 FindHighRateEquip 对 score 为0的行按 winrate 筛选
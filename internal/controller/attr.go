@@ -10,3 +10,73 @@ func AttrData2Redis(ctx *context.Context) {
 	err := logic.AttrData2Redis(ctx)
 	ctx.Reply(nil, errors.New(err))
 }
+
+type ReqHeroStats struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+	Version  string `json:"version"`
+	Level    int    `json:"level" binding:"min=0,max=18"`
+}
+
+func HeroStats(ctx *context.Context) {
+	req := &ReqHeroStats{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	stats, err := logic.HeroStats(ctx, req.Platform, req.HeroId, req.Version, req.Level)
+	ctx.Reply(stats, errors.New(err))
+}
+
+type ReqDamageCalc struct {
+	Platform int                  `form:"platform" json:"platform" binding:"-"`
+	HeroId   string               `json:"id" binding:"required"`
+	Version  string               `json:"version"`
+	Level    int                  `json:"level" binding:"required,min=1,max=18"`
+	Items    []string             `json:"items" binding:"max=6"`
+	Runes    []string             `json:"runes"`
+	Target   *ReqDamageCalcTarget `json:"target"`
+}
+
+type ReqDamageCalcTarget struct {
+	HeroId             string  `json:"id"`
+	Level              int     `json:"level" binding:"min=0,max=18"`
+	Hp                 float64 `json:"hp"`
+	Armor              float64 `json:"armor"`
+	MagicBlock         float64 `json:"magicBlock"`
+	ArmorReduction     float64 `json:"armorReduction"`
+	ArmorReductionRate float64 `json:"armorReductionRate" binding:"min=0,max=100"`
+	MagicReduction     float64 `json:"magicReduction"`
+	MagicReductionRate float64 `json:"magicReductionRate" binding:"min=0,max=100"`
+}
+
+func DamageCalc(ctx *context.Context) {
+	req := &ReqDamageCalc{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	params := &logic.DamageParams{
+		Platform: req.Platform,
+		HeroID:   req.HeroId,
+		Version:  req.Version,
+		Level:    req.Level,
+		Items:    req.Items,
+		Runes:    req.Runes,
+	}
+	if req.Target != nil {
+		params.Target = &logic.DamageTargetParams{
+			HeroID:             req.Target.HeroId,
+			Level:              req.Target.Level,
+			Hp:                 req.Target.Hp,
+			Armor:              req.Target.Armor,
+			MagicBlock:         req.Target.MagicBlock,
+			ArmorReduction:     req.Target.ArmorReduction,
+			ArmorReductionRate: req.Target.ArmorReductionRate,
+			MagicReduction:     req.Target.MagicReduction,
+			MagicReductionRate: req.Target.MagicReductionRate,
+		}
+	}
+	result, err := logic.DamageCalc(ctx, params)
+	ctx.Reply(result, errors.New(err))
+}
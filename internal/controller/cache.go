@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+func CacheStats(ctx *context.Context) {
+	stats, err := logic.CacheStats(ctx)
+	ctx.Reply(stats, errors.New(err))
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+type ReqCompare struct {
+	Type  string            `json:"type" binding:"required,oneof=equip hero rune skill"`
+	Items []*ReqCompareItem `json:"items" binding:"required,min=2,max=5,dive"`
+}
+
+type ReqCompareItem struct {
+	ID       string `json:"id" binding:"required"`
+	Version  string `json:"version"`
+	Platform int    `json:"platform" binding:"-"`
+}
+
+func Compare(ctx *context.Context) {
+	req := &ReqCompare{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	params := make([]*logic.CompareParams, 0, len(req.Items))
+	for _, item := range req.Items {
+		params = append(params, &logic.CompareParams{
+			ID:       item.ID,
+			Version:  item.Version,
+			Platform: item.Platform,
+		})
+	}
+	result, err := logic.Compare(ctx, req.Type, params)
+	ctx.Reply(result, errors.New(err))
+}
@@ -11,6 +11,7 @@ type ReqGetEquipHeroSuit struct {
 	Platform int    `form:"platform" json:"platform" binding:"-"`
 	EquipId  int    `json:"id"`
 	Version  string `json:"version"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"` // 段位，0为不区分段位
 }
 
 func GetEquipHeroSuit(ctx *context.Context) {
@@ -19,6 +20,44 @@ func GetEquipHeroSuit(ctx *context.Context) {
 		return
 	}
 
-	suit, err := logic.GetEquipHeroSuit(ctx, req.Platform, cast.ToString(req.EquipId))
+	suit, err := logic.GetEquipHeroSuit(ctx, req.Platform, cast.ToString(req.EquipId), req.Tier)
 	ctx.Reply(suit, errors.New(err))
 }
+
+type ReqEquipBuild struct {
+	Platform int      `form:"platform" json:"platform" binding:"-"`
+	IDs      []string `json:"ids" binding:"required,min=1,max=6"`
+	Version  string   `json:"version"`
+	Map      string   `json:"map"`
+}
+
+func EquipBuild(ctx *context.Context) {
+	req := &ReqEquipBuild{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	build, err := logic.EquipBuild(ctx, req.Platform, req.Version, req.Map, req.IDs)
+	ctx.Reply(build, errors.New(err))
+}
+
+type ReqLOLMEquip struct {
+	Platform     int    `form:"platform" json:"platform" binding:"-"`
+	Version      string `json:"version"`
+	Tier         string `json:"tier" binding:"omitempty,oneof=basic advanced legendary enchant"`
+	ComposeLevel int    `json:"composeLevel" binding:"min=0"` // 合成等级，0为不区分
+}
+
+func GetLOLMEquips(ctx *context.Context) {
+	req := &ReqLOLMEquip{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	equips, err := logic.GetLOLMEquips(ctx, &logic.LOLMEquipParams{
+		Version:      req.Version,
+		Tier:         req.Tier,
+		ComposeLevel: req.ComposeLevel,
+	})
+	ctx.Reply(equips, errors.New(err))
+}
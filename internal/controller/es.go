@@ -30,6 +30,9 @@ type ReqQuery struct {
 		} `json:"maps"`
 	} `json:"switch,omitempty" form:"switch,omitempty"`
 	Map []string `json:"map,omitempty" form:"map,omitempty"`
+
+	Tier         string `json:"tier,omitempty" form:"tier,omitempty"`                 // 装备等级
+	ComposeLevel string `json:"composeLevel,omitempty" form:"composeLevel,omitempty"` // 装备合成等级，仅LOLM
 }
 
 type RespQuery struct {
@@ -86,6 +89,9 @@ func Query(ctx *context.Context) {
 		Category: req.Category,
 		Way:      way,
 		Map:      maps,
+
+		Tier:         req.Tier,
+		ComposeLevel: req.ComposeLevel,
 	}
 
 	result, err := logic.EsSearch(ctx, &params)
@@ -93,7 +99,9 @@ func Query(ctx *context.Context) {
 		ctx.Reply(nil, errors.New(err))
 	}
 
-	resp := dto.SearchResult{}
+	resp := dto.SearchResult{
+		Facets: result.Facets,
+	}
 	total := result.Total.Value
 	display := len(result.Hits)
 	resp.Tips = fmt.Sprintf("为您找到相关结果约%d个", total)
@@ -127,8 +127,16 @@ func EquipExtract(ctx *context.Context) {
 }
 
 type ReqEquipFilter struct {
-	Platform string          `form:"platform" json:"platform" binding:"-"`
-	Keywords map[string]bool `json:"keywords"`
+	Platform     string             `form:"platform" json:"platform" binding:"-"`
+	Keywords     map[string]bool    `json:"keywords"`
+	Map          string             `json:"map"`
+	MinPrice     float64            `json:"minPrice" binding:"min=0"`
+	MaxPrice     float64            `json:"maxPrice" binding:"min=0"`
+	Stats        map[string]float64 `json:"stats"`
+	Tier         string             `json:"tier" binding:"omitempty,oneof=basic epic advanced legendary enchant"`
+	ComposeLevel int                `json:"composeLevel" binding:"min=0"` // 合成等级，仅LOLM
+	Sort         string             `json:"sort"`
+	Order        string             `json:"order" binding:"omitempty,oneof=asc desc"`
 }
 
 func EquipFilter(ctx *context.Context) {
@@ -153,10 +161,24 @@ func EquipFilter(ctx *context.Context) {
 	platform, err := strconv.Atoi(req.Platform)
 	if err != nil {
 		ctx.Reply(nil, errors.New(err, errors.ErrNoInvalidInput))
+		return
+	}
+	equips, facets, err := logic.FilterKeyWords(ctx, &logic.EquipFilterCond{
+		Keywords:     words,
+		Platform:     platform,
+		Map:          req.Map,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		Stats:        req.Stats,
+		Tier:         req.Tier,
+		Sort:         req.Sort,
+		Order:        req.Order,
+		ComposeLevel: req.ComposeLevel,
+	})
+
+	resp := dto.SearchResult{
+		Facets: facets,
 	}
-	equips, err := logic.FilterKeyWords(ctx, words, platform)
-
-	resp := dto.SearchResult{}
 	total := len(equips)
 	resp.Tips = fmt.Sprintf("为您找到相关结果约%d个", total)
 
@@ -222,6 +244,8 @@ func SuitHeroData2Redis(ctx *context.Context) {
 type ReqGetHeroSuit struct {
 	Platform int    `form:"platform" json:"platform" binding:"-"`
 	HeroId   string `json:"hero_id"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"`                            // 段位，0为未指定，LOLM取钻石以上
+	Mode     string `json:"mode" binding:"omitempty,oneof=classic aram arena urf"` // 模式，召唤师技能只推荐该模式可用的
 }
 
 func GetHeroSuit(ctx *context.Context) {
@@ -230,7 +254,7 @@ func GetHeroSuit(ctx *context.Context) {
 		return
 	}
 
-	suit, err := logic.GetHeroSuit(ctx, req.HeroId)
+	suit, err := logic.GetHeroSuitForMode(ctx, req.HeroId, req.Tier, req.Mode)
 	ctx.Reply(suit, errors.New(err))
 }
 
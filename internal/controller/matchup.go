@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+type ReqHeroMatchup struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+	Pos      string `json:"pos"`
+	Version  string `json:"version"`
+	MinGames int    `json:"minGames" binding:"min=0"`
+	Limit    int    `json:"limit" binding:"min=0,max=50"`
+}
+
+func GetHeroMatchup(ctx *context.Context) {
+	req := &ReqHeroMatchup{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	matchup, err := logic.GetHeroMatchup(ctx, req.Platform, req.HeroId, req.Pos, req.Version, req.MinGames, req.Limit)
+	ctx.Reply(matchup, errors.New(err))
+}
+
+func GetHeroCounter(ctx *context.Context) {
+	req := &ReqHeroMatchup{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	counter, err := logic.GetHeroCounter(ctx, req.Platform, req.HeroId, req.Pos, req.Version, req.MinGames, req.Limit)
+	ctx.Reply(counter, errors.New(err))
+}
+
+type ReqHeroTimeWinrate struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+	Pos      string `json:"pos"`
+	Version  string `json:"version"`
+}
+
+func GetHeroTimeWinrate(ctx *context.Context) {
+	req := &ReqHeroTimeWinrate{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	curve, err := logic.GetHeroTimeWinrate(ctx, req.Platform, req.HeroId, req.Pos, req.Version)
+	ctx.Reply(curve, errors.New(err))
+}
+
+type ReqHeroPartners struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+	Pos      string `json:"pos"`
+	Version  string `json:"version"`
+	MinGames int    `json:"minGames" binding:"min=0"`
+	Limit    int    `json:"limit" binding:"min=0,max=50"`
+}
+
+func GetHeroPartners(ctx *context.Context) {
+	req := &ReqHeroPartners{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	partners, err := logic.GetHeroPartners(ctx, req.Platform, req.HeroId, req.Pos, req.Version, req.MinGames, req.Limit)
+	ctx.Reply(partners, errors.New(err))
+}
+
+type ReqPairScore struct {
+	Platform  int    `form:"platform" json:"platform" binding:"-"`
+	HeroId    string `json:"id" binding:"required"`
+	PartnerId string `json:"partnerId" binding:"required"`
+	Version   string `json:"version"`
+}
+
+func GetPairScore(ctx *context.Context) {
+	req := &ReqPairScore{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	score, err := logic.GetPairScore(ctx, req.Platform, req.HeroId, req.PartnerId, req.Version)
+	ctx.Reply(score, errors.New(err))
+}
@@ -36,6 +36,25 @@ func GetRoadmap(ctx *context.Context) {
 	ctx.Reply(roadmap, errors.New(err))
 }
 
+type ReqEquipFinish struct {
+	Owned    []string `form:"owned" json:"owned"`
+	Gold     int      `form:"gold" json:"gold" binding:"min=0"`
+	Target   string   `form:"target" json:"target"`
+	Version  string   `form:"version" json:"version"`
+	Maps     []string `json:"map,omitempty" form:"map,omitempty"`
+	Platform int      `form:"platform" json:"platform" binding:"-"`
+}
+
+func EquipFinish(ctx *context.Context) {
+	req := &ReqEquipFinish{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	result, err := logic.EquipFinish(ctx, req.Platform, req.Version, req.Maps, req.Owned, req.Gold, req.Target)
+
+	ctx.Reply(result, errors.New(err))
+}
+
 func GetHotKey(ctx *context.Context) {
 	keys := logic.GetHotKey(ctx)
 	ctx.Reply(keys, nil)
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+type ReqSuggestMapping struct {
+	Type string `json:"type" binding:"omitempty,oneof=hero equip rune skill"` // 为空时处理所有类型
+}
+
+func SuggestPlatformMapping(ctx *context.Context) {
+	req := &ReqSuggestMapping{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	result, err := logic.SuggestPlatformMapping(ctx, req.Type)
+	ctx.Reply(result, errors.New(err))
+}
+
+type ReqOverrideMapping struct {
+	Type   string `json:"type" binding:"required,oneof=hero equip rune skill"`
+	LolId  string `json:"lolId" binding:"required"`
+	LolmId string `json:"lolmId"` // 为空表示在LOLM中没有对应
+}
+
+func OverridePlatformMapping(ctx *context.Context) {
+	req := &ReqOverrideMapping{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	result, err := logic.OverridePlatformMapping(ctx, req.Type, req.LolId, req.LolmId)
+	ctx.Reply(result, errors.New(err))
+}
+
+type ReqPlatformMapping struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	Type     string `json:"type" binding:"required,oneof=hero equip rune skill"`
+	ID       string `json:"id" binding:"required"`
+}
+
+func GetOtherPlatform(ctx *context.Context) {
+	req := &ReqPlatformMapping{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	result, err := logic.GetOtherPlatform(ctx, req.Type, req.Platform, req.ID)
+	ctx.Reply(result, errors.New(err))
+}
+
+func ComparePlatforms(ctx *context.Context) {
+	req := &ReqPlatformMapping{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	result, err := logic.ComparePlatforms(ctx, req.Type, req.Platform, req.ID)
+	ctx.Reply(result, errors.New(err))
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+type ReqWeekFree struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	Type     string `json:"type" binding:"omitempty,oneof=week aram permanent"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"`
+}
+
+func GetWeekFree(ctx *context.Context) {
+	req := &ReqWeekFree{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	data, err := logic.GetWeekFreeHeroes(ctx, req.Platform, req.Type, req.Tier)
+	ctx.Reply(data, errors.New(err))
+}
+
+type ReqHeroLastFree struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+}
+
+func GetHeroLastFree(ctx *context.Context) {
+	req := &ReqHeroLastFree{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	data, err := logic.GetHeroLastFree(ctx, req.Platform, req.HeroId)
+	ctx.Reply(data, errors.New(err))
+}
+
+type ReqRotationCalendar struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	Type     string `json:"type" binding:"omitempty,oneof=week aram permanent"`
+	Start    string `json:"start"` // 2006-01-02，默认为12周前
+	End      string `json:"end"`   // 2006-01-02，默认为今天
+}
+
+func GetRotationCalendar(ctx *context.Context) {
+	req := &ReqRotationCalendar{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	data, err := logic.GetRotationCalendar(ctx, req.Platform, req.Type, req.Start, req.End)
+	ctx.Reply(data, errors.New(err))
+}
@@ -10,6 +10,7 @@ type ReqGetRuneHeroSuit struct {
 	Platform int    `form:"platform" json:"platform" binding:"-"`
 	RuneId   string `json:"id"`
 	Version  string `json:"version"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"` // 段位，0为不区分段位
 }
 
 func GetRuneHeroSuit(ctx *context.Context) {
@@ -18,6 +19,62 @@ func GetRuneHeroSuit(ctx *context.Context) {
 		return
 	}
 
-	suit, err := logic.GetRuneHeroSuit(ctx, req.Platform, req.RuneId)
+	suit, err := logic.GetRuneHeroSuit(ctx, req.Platform, req.RuneId, req.Tier)
 	ctx.Reply(suit, errors.New(err))
 }
+
+type ReqHeroRunePages struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+	Pos      string `json:"pos"`
+	Version  string `json:"version"`
+}
+
+func GetHeroRunePages(ctx *context.Context) {
+	req := &ReqHeroRunePages{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	pages, err := logic.GetHeroRunePages(ctx, req.Platform, req.HeroId, req.Pos, req.Version)
+	ctx.Reply(pages, errors.New(err))
+}
+
+type ReqRuneTree struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	Version  string `json:"version"`
+}
+
+func GetRuneTree(ctx *context.Context) {
+	req := &ReqRuneTree{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	tree, err := logic.GetRuneTree(ctx, req.Platform, req.Version)
+	ctx.Reply(tree, errors.New(err))
+}
+
+type ReqRunePageCheck struct {
+	Platform  int      `form:"platform" json:"platform" binding:"-"`
+	Version   string   `json:"version"`
+	Primary   string   `json:"primary"`                               // 主系名称，为空时取基石所在的系，仅LOL
+	Secondary string   `json:"secondary"`                             // 副系名称，仅LOL
+	Runes     []string `json:"runes" binding:"required,min=1,max=12"` // 符文和属性碎片的ID
+}
+
+func CheckRunePage(ctx *context.Context) {
+	req := &ReqRunePageCheck{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	result, err := logic.CheckRunePage(ctx, &logic.RunePageCheckParams{
+		Platform:  req.Platform,
+		Version:   req.Version,
+		Primary:   req.Primary,
+		Secondary: req.Secondary,
+		Runes:     req.Runes,
+	})
+	ctx.Reply(result, errors.New(err))
+}
@@ -10,6 +10,7 @@ type ReqGetSkillHeroSuit struct {
 	Platform int    `form:"platform" json:"platform" binding:"-"`
 	RuneId   string `json:"id"`
 	Version  string `json:"version"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"` // 段位，0为不区分段位
 }
 
 func GetSkillHeroSuit(ctx *context.Context) {
@@ -18,6 +19,22 @@ func GetSkillHeroSuit(ctx *context.Context) {
 		return
 	}
 
-	suit, err := logic.GetSkillHeroSuit(ctx, req.Platform, req.RuneId)
+	suit, err := logic.GetSkillHeroSuit(ctx, req.Platform, req.RuneId, req.Tier)
 	ctx.Reply(suit, errors.New(err))
 }
+
+type ReqSkills struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	Mode     string `json:"mode" binding:"omitempty,oneof=classic aram arena urf"`
+	Level    int    `json:"level" binding:"min=0"` // 召唤师等级，0为不限
+}
+
+func GetSkills(ctx *context.Context) {
+	req := &ReqSkills{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	skills, err := logic.GetSkills(ctx, req.Platform, req.Mode, req.Level)
+	ctx.Reply(skills, errors.New(err))
+}
@@ -10,8 +10,9 @@ import (
 )
 
 type ReqGetHeroSkins struct {
-	Platform int    `form:"platform" json:"platform" binding:"-"`
-	HeroId   string `json:"id"`
+	Platform    int    `form:"platform" json:"platform" binding:"-"`
+	HeroId      string `json:"id"`
+	WithChromas bool   `json:"withChromas"` // 是否包含炫彩，默认不包含
 }
 
 func GetHeroSkins(ctx *context.Context) {
@@ -28,12 +29,12 @@ func GetHeroSkins(ctx *context.Context) {
 			heroID = attrs[idx].HeroId
 		}
 	}
-	attr, err := logic.GetAttribute(ctx, req.Platform, heroID)
+	attr, err := logic.GetAttribute(ctx, req.Platform, heroID, "")
 	if err != nil {
 		ctx.Reply(nil, errors.New(err))
 	}
 
-	skins, err := logic.GetHeroSkins(ctx, req.Platform, heroID)
+	skins, err := logic.GetHeroSkins(ctx, req.Platform, heroID, req.WithChromas)
 	var resp []*dto.RespHeroSkins
 	for _, skin := range skins {
 		desc := skin.Description
@@ -64,3 +65,52 @@ func GetHeroSkins(ctx *context.Context) {
 	}
 	ctx.Reply(resp, errors.New(err))
 }
+
+type ReqSkinCatalog struct {
+	Platform   int    `form:"platform" json:"platform" binding:"-"`
+	HeroId     string `json:"heroId"`
+	SuitType   string `json:"suitType"`
+	Year       string `json:"year"`
+	HasChromas *bool  `json:"hasChromas"` // 为空时不过滤
+	Page       int    `json:"page" binding:"min=0"`
+	PageSize   int    `json:"pageSize" binding:"min=0,max=100"`
+}
+
+func GetSkinCatalog(ctx *context.Context) {
+	req := &ReqSkinCatalog{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PageSize == 0 {
+		req.PageSize = 20
+	}
+
+	data, err := logic.GetSkinCatalog(ctx, &logic.SkinCatalogParams{
+		Platform:   req.Platform,
+		HeroID:     req.HeroId,
+		SuitType:   req.SuitType,
+		Year:       req.Year,
+		HasChromas: req.HasChromas,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	})
+	ctx.Reply(data, errors.New(err))
+}
+
+type ReqLatestSkins struct {
+	Platform int `form:"platform" json:"platform" binding:"-"`
+	Limit    int `json:"limit" binding:"min=0,max=100"`
+}
+
+func GetLatestSkins(ctx *context.Context) {
+	req := &ReqLatestSkins{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	data, err := logic.GetLatestSkins(ctx, req.Platform, req.Limit)
+	ctx.Reply(data, errors.New(err))
+}
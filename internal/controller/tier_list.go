@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"whisper/internal/dto"
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+type ReqTierList struct {
+	Platform    int     `form:"platform" json:"platform" binding:"-"`
+	Version     string  `json:"version"`
+	Pos         string  `json:"pos"`
+	Level       int     `json:"level" binding:"min=0,max=4"`
+	WinWeight   float64 `json:"winWeight" binding:"min=0"`
+	PickWeight  float64 `json:"pickWeight" binding:"min=0"`
+	BanWeight   float64 `json:"banWeight" binding:"min=0"`
+	MinShowRate int     `json:"minShowRate" binding:"min=0"`
+}
+
+func TierList(ctx *context.Context) {
+	req := &ReqTierList{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	// 权重都没传时使用默认公式
+	formula := dto.TierFormula{
+		WinWeight:   req.WinWeight,
+		PickWeight:  req.PickWeight,
+		BanWeight:   req.BanWeight,
+		MinShowRate: req.MinShowRate,
+	}
+	if formula.WinWeight == 0 && formula.PickWeight == 0 && formula.BanWeight == 0 {
+		formula.WinWeight = logic.DefaultTierWinWeight
+		formula.PickWeight = logic.DefaultTierPickWeight
+		formula.BanWeight = logic.DefaultTierBanWeight
+	}
+	if formula.MinShowRate == 0 {
+		formula.MinShowRate = logic.DefaultTierMinShowRate
+	}
+
+	tierList, err := logic.GetTierList(ctx, &logic.TierListParams{
+		Platform: req.Platform,
+		Version:  req.Version,
+		Pos:      req.Pos,
+		Level:    req.Level,
+		Formula:  formula,
+	})
+	ctx.Reply(tierList, errors.New(err))
+}
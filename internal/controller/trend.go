@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"whisper/internal/logic"
+	"whisper/pkg/context"
+	"whisper/pkg/errors"
+)
+
+type ReqHeroTrend struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	HeroId   string `json:"id" binding:"required"`
+	Pos      string `json:"pos"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"`
+	Start    string `json:"start"` // 2006-01-02，默认为30天前
+	End      string `json:"end"`   // 2006-01-02，默认为今天
+}
+
+func GetHeroTrend(ctx *context.Context) {
+	req := &ReqHeroTrend{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+
+	trend, err := logic.GetHeroTrend(ctx, req.Platform, req.HeroId, req.Pos, req.Tier, req.Start, req.End)
+	ctx.Reply(trend, errors.New(err))
+}
+
+type ReqHeroMovers struct {
+	Platform int    `form:"platform" json:"platform" binding:"-"`
+	Pos      string `json:"pos"`
+	Tier     int    `json:"tier" binding:"min=0,max=4"`
+	Days     int    `json:"days" binding:"min=0,max=90"`
+	Metric   string `json:"metric" binding:"omitempty,oneof=win pick ban"`
+	Limit    int    `json:"limit" binding:"min=0,max=50"`
+}
+
+func GetHeroMovers(ctx *context.Context) {
+	req := &ReqHeroMovers{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	movers, err := logic.GetHeroMovers(ctx, req.Platform, req.Pos, req.Tier, req.Days, req.Metric, req.Limit)
+	ctx.Reply(movers, errors.New(err))
+}
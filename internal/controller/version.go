@@ -79,3 +79,34 @@ func VersionDetail(ctx *context.Context) {
 	detail, err := logic.VersionDetail(ctx, req.Platform, req.Version, req.ID)
 	ctx.Reply(detail, errors.New(err))
 }
+
+type VersionImpactReq struct {
+	Platform int    `json:"platform" form:"platform"`
+	Version  string `json:"version" form:"version"`
+	ID       string `json:"id" form:"id"`
+	Date     string `json:"date" form:"date"` // 版本发布日期 2006-01-02，为空时从版本列表中获取
+	Tier     int    `json:"tier" form:"tier" binding:"min=0,max=4"`
+	Days     int    `json:"days" form:"days" binding:"min=0,max=30"`
+	Limit    int    `json:"limit" form:"limit" binding:"min=0,max=50"`
+}
+
+func VersionImpact(ctx *context.Context) {
+	req := &VersionImpactReq{}
+	if err := ctx.Bind(req); err != nil {
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	impact, err := logic.PatchImpact(ctx, &logic.PatchImpactParams{
+		Platform: req.Platform,
+		Vkey:     req.Version,
+		ID:       req.ID,
+		Date:     req.Date,
+		Tier:     req.Tier,
+		Days:     req.Days,
+		Limit:    req.Limit,
+	})
+	ctx.Reply(impact, errors.New(err))
+}
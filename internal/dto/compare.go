@@ -0,0 +1,24 @@
+package dto
+
+type RespCompare struct {
+	Type     string           `json:"type"`
+	Entities []*CompareEntity `json:"entities"`
+	Fields   []*CompareField  `json:"fields"`
+}
+
+type CompareEntity struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Version  string `json:"version"`
+	Platform int    `json:"platform"`
+}
+
+// CompareField 对比矩阵中的一行，Values 与 Entities 一一对应
+type CompareField struct {
+	Field   string        `json:"field"`
+	Values  []interface{} `json:"values"`
+	Diff    bool          `json:"diff"`            // 各实体的值是否不同
+	Delta   []float64     `json:"delta,omitempty"` // 数值类字段相对第一个实体的差值
+	BestIdx int           `json:"bestIdx"`         // 数值类字段中最优值的下标，价格、冷却等越小越好，没有最优值时为 -1
+}
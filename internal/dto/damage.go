@@ -0,0 +1,42 @@
+package dto
+
+type RespDamageCalc struct {
+	Hero    *HeroLevelStats    `json:"hero"`  // 白字属性
+	Bonus   map[string]float64 `json:"bonus"` // 装备和符文提供的属性
+	Offense *DamageOffense     `json:"offense"`
+	Defense *DamageDefense     `json:"defense"`
+	Target  *DamageTarget      `json:"target"`
+
+	DamagePerAttack float64 `json:"damagePerAttack"` // 对目标单次普攻的期望伤害（含暴击）
+	DPS             float64 `json:"dps"`             // 普攻的期望秒伤
+}
+
+type DamageOffense struct {
+	AD            float64 `json:"ad"`
+	AP            float64 `json:"ap"`
+	AttackSpeed   float64 `json:"attackSpeed"`
+	CritRate      float64 `json:"critRate"`
+	CritDamage    float64 `json:"critDamage"`
+	ArmorPene     float64 `json:"armorPene"`
+	ArmorPeneRate float64 `json:"armorPeneRate"`
+	MagicPene     float64 `json:"magicPene"`
+	MagicPeneRate float64 `json:"magicPeneRate"`
+}
+
+type DamageDefense struct {
+	Hp          float64 `json:"hp"`
+	Armor       float64 `json:"armor"`
+	MagicBlock  float64 `json:"magicBlock"`
+	EHPPhysical float64 `json:"ehpPhysical"` // 对物理伤害的有效生命值
+	EHPMagic    float64 `json:"ehpMagic"`    // 对魔法伤害的有效生命值
+}
+
+type DamageTarget struct {
+	Hp                  float64 `json:"hp"`
+	Armor               float64 `json:"armor"`
+	MagicBlock          float64 `json:"magicBlock"`
+	EffectiveArmor      float64 `json:"effectiveArmor"`
+	EffectiveMagicBlock float64 `json:"effectiveMagicBlock"`
+	PhysicalMultiplier  float64 `json:"physicalMultiplier"`
+	MagicMultiplier     float64 `json:"magicMultiplier"`
+}
@@ -94,10 +94,21 @@ type SubCate struct {
 // --------------------------------------------------------
 
 type RespRoadmap struct {
-	Current      Roadmap   `json:"current"`
-	From         []Roadmap `json:"from"`
-	Into         []Roadmap `json:"into"`
-	GapPriceFrom int       `json:"gapPriceFrom"`
+	Current      Roadmap        `json:"current"`
+	From         []Roadmap      `json:"from"`
+	Into         []Roadmap      `json:"into"`
+	GapPriceFrom int            `json:"gapPriceFrom"`
+	Tree         *RoadmapNode   `json:"tree"`      // 完整的合成树（向下）
+	IntoTree     []*RoadmapNode `json:"intoTree"`  // 完整的可合成图（向上）
+	TotalCost    int            `json:"totalCost"` // 合成树中所有合成费用之和
+}
+
+// RoadmapNode 合成树节点
+type RoadmapNode struct {
+	Roadmap
+	Count       int            `json:"count"`       // 上一级合成需要的数量
+	CombineCost int            `json:"combineCost"` // 本节点的合成费用 = 总价 - 配件总价
+	Children    []*RoadmapNode `json:"children"`
 }
 
 type Roadmap struct {
@@ -112,4 +123,77 @@ type Roadmap struct {
 	Sell      int    `json:"sell"`
 	Version   string `json:"version"`
 	Platform  int    `json:"platform"`
+
+	Tier         string `json:"tier,omitempty"`         // basic/epic/advanced/legendary/enchant
+	ComposeLevel int    `json:"composeLevel,omitempty"` // 合成等级，仅LOLM
+}
+
+// RespEquipFinish 根据已有装备和金币计算可以合成的装备
+type RespEquipFinish struct {
+	Gold          int                `json:"gold"`
+	Version       string             `json:"version"`
+	Buyable       []*EquipFinishItem `json:"buyable"`       // 当前金币可以直接合成的成装
+	Target        *EquipFinishItem   `json:"target"`        // 指定的目标装备
+	NextComponent *EquipFinishItem   `json:"nextComponent"` // 朝目标装备前进时最便宜的下一件配件
+}
+
+type EquipFinishItem struct {
+	Roadmap
+	RemainCost int   `json:"remainCost"` // 扣除已有配件后的剩余花费
+	UsedOwned  []int `json:"usedOwned"`  // 被抵扣的已有装备
+	Affordable bool  `json:"affordable"`
+}
+
+// RespEquipBuild 一套出装的属性汇总和规则校验结果
+type RespEquipBuild struct {
+	Items      []Roadmap          `json:"items"`
+	Stats      map[string]float64 `json:"stats"`
+	TotalPrice int                `json:"totalPrice"`
+	SellPrice  int                `json:"sellPrice"`
+	Valid      bool               `json:"valid"`
+	Violations []*BuildViolation  `json:"violations"`
+}
+
+type BuildViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Items   []int  `json:"items"`
+}
+
+// RespLOLMEquip LOLM装备按等级分组
+type RespLOLMEquip struct {
+	Version string           `json:"version"`
+	Tiers   []*LOLMEquipTier `json:"tiers"`
+}
+
+type LOLMEquipTier struct {
+	Tier  string           `json:"tier"`
+	Name  string           `json:"name"`
+	Items []*LOLMEquipItem `json:"items"`
+}
+
+type LOLMEquipItem struct {
+	ID           int          `json:"ID"`
+	Name         string       `json:"name"`
+	Icon         string       `json:"icon"`
+	Desc         string       `json:"desc"`
+	Price        int          `json:"price"`
+	Tier         string       `json:"tier"`
+	ComposeLevel int          `json:"composeLevel"`
+	Type         string       `json:"type"`
+	Tags         []string     `json:"tags"`
+	Stats        []*EquipStat `json:"stats"`
+	Actives      []string     `json:"actives"`  // 主动效果
+	Passives     []string     `json:"passives"` // 唯一被动
+	From         []int        `json:"from"`
+	Into         []int        `json:"into"`
+	Enchants     []int        `json:"enchants"` // 可选的附魔
+	Version      string       `json:"version"`
+}
+
+type EquipStat struct {
+	Key     string  `json:"key"`
+	Name    string  `json:"name"`
+	Value   float64 `json:"value"`
+	Percent bool    `json:"percent"` // value 为百分数
 }
@@ -4,6 +4,8 @@ type EsResultHits struct {
 	Total    Total   `json:"total,omitempty"`
 	MaxScore float64 `json:"max_score,omitempty"`
 	Hits     []Hits  `json:"hits,omitempty"`
+
+	Facets map[string]int `json:"facets,omitempty"` // 分面数量，key 为 字段:值
 }
 
 type Total struct {
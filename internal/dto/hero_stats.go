@@ -0,0 +1,28 @@
+package dto
+
+// RespHeroStats 英雄各等级的属性，攻速成长按基础攻速计算，见 common.AttackSpeedAtLevel
+type RespHeroStats struct {
+	HeroId   string            `json:"heroId"`
+	Name     string            `json:"name"`
+	Title    string            `json:"title"`
+	Version  string            `json:"version"`
+	Platform int               `json:"platform"`
+	Levels   []*HeroLevelStats `json:"levels"`
+}
+
+// HeroLevelStats 英雄在某个等级时的属性
+type HeroLevelStats struct {
+	Level            int     `json:"level"`
+	Hp               float64 `json:"hp"`
+	Mp               float64 `json:"mp"`
+	Attackdamage     float64 `json:"attackdamage"`
+	Attackspeed      float64 `json:"attackspeed"`
+	BonusAttackspeed float64 `json:"bonusAttackspeed"` // 成长获得的额外攻速百分比
+	Armor            float64 `json:"armor"`
+	Spellblock       float64 `json:"spellblock"`
+	Hpregen          float64 `json:"hpregen"`
+	Mpregen          float64 `json:"mpregen"`
+	Crit             float64 `json:"crit"`
+	Movespeed        float64 `json:"movespeed"`
+	Attackrange      float64 `json:"attackrange"`
+}
@@ -86,25 +86,26 @@ type HeroBaseInfo struct {
 	Difficulty  string `json:"difficulty,omitempty"` // 仅LOL有值
 	DifficultyL string `json:"difficultyL,omitempty"`
 
-	Attack              string `json:"attack,omitempty"`
-	AttackRange         string `json:"attackrange,omitempty"`  // 仅LOL有值
-	AttackDamage        string `json:"attackdamage,omitempty"` // 仅LOL有值
-	Attackspeed         string `json:"attackspeed,omitempty"`
-	Attackspeedperlevel string `json:"attackspeedperlevel,omitempty"`
-	Hp                  string `json:"hp,omitempty"`
-	Hpperlevel          string `json:"hpperlevel,omitempty"`
-	Mp                  string `json:"mp,omitempty"`
-	Mpperlevel          string `json:"mpperlevel,omitempty"`
-	Movespeed           string `json:"movespeed,omitempty"`
-	Armor               string `json:"armor,omitempty"`
-	Armorperlevel       string `json:"armorperlevel,omitempty"`
-	Spellblock          string `json:"spellblock,omitempty"`
-	Spellblockperlevel  string `json:"spellblockperlevel,omitempty"`
-	Hpregen             string `json:"hpregen,omitempty"`
-	Hpregenperlevel     string `json:"hpregenperlevel,omitempty"`
-	Mpregen             string `json:"mpregen,omitempty"`
-	Mpregenperlevel     string `json:"mpregenperlevel,omitempty"`
-	Crit                string `json:"crit,omitempty"`
+	Attack               string `json:"attack,omitempty"`
+	AttackRange          string `json:"attackrange,omitempty"`  // 仅LOL有值
+	AttackDamage         string `json:"attackdamage,omitempty"` // 仅LOL有值
+	AttackDamagePerLevel string `json:"attackdamageperlevel,omitempty"`
+	Attackspeed          string `json:"attackspeed,omitempty"`
+	Attackspeedperlevel  string `json:"attackspeedperlevel,omitempty"`
+	Hp                   string `json:"hp,omitempty"`
+	Hpperlevel           string `json:"hpperlevel,omitempty"`
+	Mp                   string `json:"mp,omitempty"`
+	Mpperlevel           string `json:"mpperlevel,omitempty"`
+	Movespeed            string `json:"movespeed,omitempty"`
+	Armor                string `json:"armor,omitempty"`
+	Armorperlevel        string `json:"armorperlevel,omitempty"`
+	Spellblock           string `json:"spellblock,omitempty"`
+	Spellblockperlevel   string `json:"spellblockperlevel,omitempty"`
+	Hpregen              string `json:"hpregen,omitempty"`
+	Hpregenperlevel      string `json:"hpregenperlevel,omitempty"`
+	Mpregen              string `json:"mpregen,omitempty"`
+	Mpregenperlevel      string `json:"mpregenperlevel,omitempty"`
+	Crit                 string `json:"crit,omitempty"`
 
 	Damage     string `json:"damage,omitempty"`
 	Durability string `json:"durability,omitempty"`
@@ -253,19 +254,19 @@ type ChampionLaneItem struct {
 	Core3itemjson string `json:"core3itemjson,omitempty"`
 	Shoesjson     string `json:"shoesjson,omitempty"`
 	Hold3         string `json:"hold3,omitempty"`
+	Perkdetail    string `json:"perkdetail,omitempty"` // 符文页
+	Igamecnt      string `json:"igamecnt,omitempty"`
+	Wincnt        string `json:"wincnt,omitempty"`
+	Lanewinrate   string `json:"lanewinrate,omitempty"` // 该位置的胜率，万分比
+	Lanshowrate   string `json:"lanshowrate,omitempty"` // 该位置的出场率，万分比
 
 	//Dtstatdate          string `json:"dtstatdate,omitempty"`
 	//Championid          string `json:"championid,omitempty"`
 	//Gameversion         string `json:"gameversion,omitempty"`
 	//Lane                string `json:"lane,omitempty"`
-	//Wincnt              string `json:"wincnt,omitempty"`
-	//Igamecnt            string `json:"igamecnt,omitempty"`
 	//Lanrate             string `json:"lanrate,omitempty"`
-	//Lanewinrate         string `json:"lanewinrate,omitempty"`
-	//Lanshowrate         string `json:"lanshowrate,omitempty"`
 	//Champlanorder       string `json:"champlanorder,omitempty"`
 	//Mainviceperk        string `json:"mainviceperk,omitempty"`
-	//Perkdetail          string `json:"perkdetail,omitempty"`
 	//Spellidjson         string `json:"spellidjson,omitempty"`
 	//Skilljson           string `json:"skilljson,omitempty"`
 	//WinrateFlowPlaytime string `json:"winrate_flow_playtime,omitempty"`
@@ -291,6 +292,17 @@ type ChampionLaneItem struct {
 	//Hold10              string `json:"hold10,omitempty"`
 	//EtlStamp            string `json:"etl_stamp,omitempty"`
 }
+
+// ChampionFightItem championFight中每个位置下的对位数据，胜率为万分比
+type ChampionFightItem struct {
+	Championid  string `json:"championid"`
+	Championid2 string `json:"championid2"` // 对手
+	Lane        string `json:"lane"`
+	Igamecnt    int32  `json:"igamecnt"`
+	Wincnt      int32  `json:"wincnt"`
+	Winrate     int32  `json:"winrate"`
+}
+
 type ChampionFight struct {
 	Bottom  interface{} `json:"bottom"`
 	Mid     interface{} `json:"mid"`
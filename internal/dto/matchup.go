@@ -0,0 +1,27 @@
+package dto
+
+type RespHeroMatchup struct {
+	HeroId  string     `json:"heroId"`
+	Pos     string     `json:"pos"`
+	Version string     `json:"version"`
+	Best    []*Matchup `json:"best"`  // 优势对位
+	Worst   []*Matchup `json:"worst"` // 劣势对位
+}
+
+type RespHeroCounter struct {
+	HeroId   string     `json:"heroId"`
+	Pos      string     `json:"pos"`
+	Version  string     `json:"version"`
+	Counters []*Matchup `json:"counters"` // 克制该英雄的英雄，胜率为克制者的胜率
+}
+
+type Matchup struct {
+	HeroId   string  `json:"heroId"`
+	Name     string  `json:"name"`
+	Avatar   string  `json:"avatar"`
+	Pos      string  `json:"pos"`
+	Games    int32   `json:"games"`
+	Wins     int32   `json:"wins"`
+	WinRate  float64 `json:"winRate"` // 百分比
+	Platform int     `json:"platform"`
+}
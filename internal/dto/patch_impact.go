@@ -0,0 +1,40 @@
+package dto
+
+type RespPatchImpact struct {
+	Platform   int            `json:"platform"`
+	Vkey       string         `json:"vkey"`
+	PatchDate  string         `json:"patchDate"`
+	Before     DateRange      `json:"before"`
+	After      DateRange      `json:"after"`
+	Heroes     []*PatchMover  `json:"heroes"`     // 有改动的英雄
+	Items      []*PatchMover  `json:"items"`      // 有改动的装备，只有LOL有装备统计数据
+	Surprising []*PatchMover  `json:"surprising"` // 没有直接改动但胜率变化明显的英雄
+	Changes    []*PatchChange `json:"changes"`    // 从版本公告中解析出的改动
+}
+
+type DateRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type PatchChange struct {
+	Category string `json:"category"` // hero/item
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Label    string `json:"label"` // buff/nerf/adjust
+	Content  string `json:"content"`
+}
+
+type PatchMover struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Icon       string  `json:"icon"`
+	Pos        string  `json:"pos,omitempty"`
+	Label      string  `json:"label,omitempty"` // buff/nerf/adjust，没有直接改动时为空
+	WinBefore  float64 `json:"winBefore"`       // 百分比
+	WinAfter   float64 `json:"winAfter"`
+	WinDelta   float64 `json:"winDelta"` // 百分点
+	PickBefore float64 `json:"pickBefore"`
+	PickAfter  float64 `json:"pickAfter"`
+	PickDelta  float64 `json:"pickDelta"`
+}
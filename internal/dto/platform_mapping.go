@@ -0,0 +1,24 @@
+package dto
+
+type MappingSummary struct {
+	Type          string `json:"type"`
+	Auto          int    `json:"auto"`          // 自动匹配的数量
+	Manual        int    `json:"manual"`        // 手动指定的数量
+	UnmatchedLOL  int    `json:"unmatchedLOL"`  // LOL中没有匹配上的数量
+	UnmatchedLOLM int    `json:"unmatchedLOLM"` // LOLM中没有匹配上的数量
+}
+
+type MappingEntity struct {
+	Platform int    `json:"platform"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon,omitempty"`
+}
+
+type RespPlatformMapping struct {
+	Type       string         `json:"type"`
+	From       *MappingEntity `json:"from"`
+	To         *MappingEntity `json:"to"`
+	Similarity float64        `json:"similarity"` // 名称相似度，百分比
+	Manual     bool           `json:"manual"`     // 是否手动指定
+}
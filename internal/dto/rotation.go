@@ -0,0 +1,42 @@
+package dto
+
+type RotationHero struct {
+	HeroId string `json:"heroId"`
+	Name   string `json:"name"`
+	Avatar string `json:"avatar"`
+}
+
+type WeekFreeHero struct {
+	RotationHero
+	Suit *HeroSuit `json:"suit,omitempty"`
+}
+
+type RespWeekFree struct {
+	Week   string          `json:"week"` // 周一的日期
+	Type   string          `json:"type"` // week/aram/permanent
+	Heroes []*WeekFreeHero `json:"heroes"`
+}
+
+type LastFree struct {
+	Type     string `json:"type"`
+	LastWeek string `json:"lastWeek"` // 为空表示没有免费过
+	Times    int    `json:"times"`    // 免费的周数
+	IsFree   bool   `json:"isFree"`   // 本周是否免费
+}
+
+type RespHeroLastFree struct {
+	RotationHero
+	Rotations []*LastFree `json:"rotations"`
+}
+
+type RotationWeek struct {
+	Week   string          `json:"week"`
+	Heroes []*RotationHero `json:"heroes"`
+}
+
+type RespRotationCalendar struct {
+	Type  string          `json:"type"`
+	Start string          `json:"start"`
+	End   string          `json:"end"`
+	Weeks []*RotationWeek `json:"weeks"`
+}
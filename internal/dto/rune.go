@@ -68,3 +68,69 @@ type RuneTypes struct {
 	SubType string `json:"sub_type,omitempty"`
 	Type    string `json:"type,omitempty"`
 }
+
+// --------------------------------------------------------
+
+// RunePage 结构化的符文页
+type RunePage struct {
+	Pos       string       `json:"pos"`
+	Primary   RuneStyle    `json:"primary"`
+	Keystone  *RuneBrief   `json:"keystone"`
+	Secondary RuneStyle    `json:"secondary"`
+	Shards    []*RuneBrief `json:"shards"`
+	Igamecnt  int32        `json:"igamecnt"`
+	Winrate   int32        `json:"winrate"`
+	Showrate  int32        `json:"showrate"`
+	Version   string       `json:"version"`
+	Platform  int          `json:"platform"`
+}
+
+type RuneStyle struct {
+	Name  string       `json:"name"`
+	Runes []*RuneBrief `json:"runes"`
+}
+
+type RuneBrief struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Icon      string `json:"icon"`
+	StyleName string `json:"styleName"`
+	SlotLabel string `json:"slotLabel"`
+}
+
+// --------------------------------------------------------
+
+// RuneTree 某个版本的符文树：系 -> 槽位 -> 符文，以及属性碎片
+type RuneTree struct {
+	Platform int              `json:"platform"`
+	Version  string           `json:"version"`
+	Styles   []*RuneTreeStyle `json:"styles"`
+	Shards   []*RuneTreeSlot  `json:"shards"`
+}
+
+type RuneTreeStyle struct {
+	Name  string          `json:"name"`
+	Slots []*RuneTreeSlot `json:"slots"`
+}
+
+type RuneTreeSlot struct {
+	Index    int          `json:"index"`
+	Label    string       `json:"label"`
+	Keystone bool         `json:"keystone"` // 每个系的第一个槽位为基石
+	Runes    []*RuneBrief `json:"runes"`
+}
+
+// RespRunePageCheck 符文页合法性校验结果
+type RespRunePageCheck struct {
+	Version    string           `json:"version"`
+	Primary    string           `json:"primary"`
+	Secondary  string           `json:"secondary"`
+	Valid      bool             `json:"valid"`
+	Violations []*RuneViolation `json:"violations"`
+}
+
+type RuneViolation struct {
+	Rule    string   `json:"rule"`
+	Message string   `json:"message"`
+	Runes   []string `json:"runes"`
+}
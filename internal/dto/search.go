@@ -3,8 +3,9 @@ package dto
 // --------------------------------------------------------
 
 type SearchResult struct {
-	Tips string              `json:"tips"`
-	List []*SearchResultList `json:"list"`
+	Tips   string              `json:"tips"`
+	List   []*SearchResultList `json:"list"`
+	Facets map[string]int      `json:"facets,omitempty"`
 }
 
 type SearchResultList struct {
@@ -22,6 +23,9 @@ type SearchResultList struct {
 	Keywords  []string `json:"keywords"`
 
 	Spell []*HeroSpell `json:"spell"`
+
+	Score     float64 `json:"score,omitempty"`     // 适配度（0~100），按平台归一化
+	LowSample bool    `json:"lowSample,omitempty"` // 样本数太少
 }
 
 type HeroSpell struct {
@@ -36,3 +36,18 @@ type SkillList struct {
 	Mode      string `json:"mode,omitempty"`
 	SortOrder string `json:"sortOrder,omitempty"`
 }
+
+// --------------------------------------------------------
+
+// SkillBrief 召唤师技能在各模式下的可用情况
+type SkillBrief struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Icon        string   `json:"icon"`
+	Description string   `json:"description"`
+	Cooldown    string   `json:"cooldown"`
+	UnlockLevel int      `json:"unlockLevel"` // 解锁需要的召唤师等级
+	Modes       []string `json:"modes"`       // classic/aram/arena/urf
+	Version     string   `json:"version"`
+	Platform    int      `json:"platform"`
+}
@@ -14,3 +14,38 @@ type RespHeroSkins struct {
 	Platform    int    `json:"platform,omitempty"`
 	Version     string `json:"version,omitempty"`
 }
+
+type SkinItem struct {
+	SkinId      string        `json:"skinId"`
+	HeroId      string        `json:"heroId"`
+	HeroName    string        `json:"heroName"`
+	HeroTitle   string        `json:"heroTitle"`
+	Name        string        `json:"name"`
+	IsBase      bool          `json:"isBase"`
+	SuitType    string        `json:"suitType"` // 皮肤系列
+	PublishTime string        `json:"publishTime"`
+	EmblemsName string        `json:"emblemsName"`
+	Description string        `json:"description"`
+	MainImg     string        `json:"mainImg"`
+	IconImg     string        `json:"iconImg"`
+	LoadingImg  string        `json:"loadingImg"`
+	VideoImg    string        `json:"videoImg"`
+	SourceImg   string        `json:"sourceImg"`
+	Chromas     []*SkinChroma `json:"chromas"`
+	Platform    int           `json:"platform"`
+	Version     string        `json:"version"`
+}
+
+type SkinChroma struct {
+	SkinId    string `json:"skinId"`
+	Name      string `json:"name"`
+	ChromaImg string `json:"chromaImg"`
+	IconImg   string `json:"iconImg"`
+}
+
+type RespSkinCatalog struct {
+	Total     int         `json:"total"`
+	SuitTypes []string    `json:"suitTypes"` // 所有的皮肤系列，用于筛选
+	Years     []string    `json:"years"`     // 所有的上线年份，用于筛选
+	Skins     []*SkinItem `json:"skins"`
+}
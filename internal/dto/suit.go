@@ -5,6 +5,19 @@ type HeroSuit struct {
 	Platform int                           `json:"platform"`
 	Equips   map[string]RecommendSuitEquip `json:"equips"` // map["top|bottom|support..."]RecommendSuitEquip
 	ExtInfo  HeroSuitExtInfo               `json:"ext_info"`
+
+	SkillOrders map[string][]*SkillOrder `json:"skill_orders"` // 同 Equips 的key
+	Positions   []*HeroPosition          `json:"positions"`    // 指定分段下各位置的数据
+}
+
+type HeroPosition struct {
+	Pos           string  `json:"pos"`
+	Level         int     `json:"level"`
+	WinRate       float64 `json:"winRate"`  // 百分比
+	ShowRate      float64 `json:"showRate"` // 百分比
+	BanRate       float64 `json:"banRate"`  // 百分比
+	Strength      string  `json:"strength,omitempty"`
+	StrengthLevel string  `json:"strengthLevel,omitempty"`
 }
 
 type HeroSuitExtInfo struct {
@@ -47,6 +60,9 @@ type SuitData struct {
 	Allcnt   int32 `json:"allcnt,omitempty"`
 	Showrate int32 `json:"showrate,omitempty"`
 
+	Score     int32 `json:"score,omitempty"`      // 胜率的Wilson下界，万分比
+	LowSample bool  `json:"low_sample,omitempty"` // 样本数太少，胜率不可信
+
 	Title        string `json:"title"`
 	Author       string `json:"author"`
 	AuthorIcon   string `json:"author_icon"`
@@ -55,3 +71,13 @@ type SuitData struct {
 
 	Platform int `json:"platform"`
 }
+
+// SkillOrder 技能加点推荐
+type SkillOrder struct {
+	Order    []string `json:"order"`    // 主升顺序，如 [Q E W]
+	Sequence []string `json:"sequence"` // 1级开始的逐级加点
+	Igamecnt int32    `json:"igamecnt,omitempty"`
+	Winrate  int32    `json:"winrate,omitempty"`
+	Showrate int32    `json:"showrate,omitempty"`
+	Version  string   `json:"version"`
+}
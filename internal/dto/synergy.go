@@ -0,0 +1,31 @@
+package dto
+
+type RespHeroPartners struct {
+	HeroId   string     `json:"heroId"`
+	Pos      string     `json:"pos"`
+	Version  string     `json:"version"`
+	Baseline float64    `json:"baseline"` // 该英雄与所有队友组合的平均胜率，百分比
+	Partners []*Partner `json:"partners"`
+}
+
+type Partner struct {
+	HeroId   string  `json:"heroId"`
+	Name     string  `json:"name"`
+	Avatar   string  `json:"avatar"`
+	Pos      string  `json:"pos"`
+	Games    int32   `json:"games"`
+	Wins     int32   `json:"wins"`
+	WinRate  float64 `json:"winRate"` // 百分比
+	Synergy  float64 `json:"synergy"` // 相对平均胜率的提升，百分点
+	Platform int     `json:"platform"`
+}
+
+type RespPairScore struct {
+	Version string   `json:"version"`
+	Hero    *Partner `json:"hero"`
+	Partner *Partner `json:"partner"`
+	Games   int32    `json:"games"`
+	WinRate float64  `json:"winRate"`
+	Synergy float64  `json:"synergy"`
+	Score   float64  `json:"score"` // 按样本数收缩后的协同分，样本越少越接近0
+}
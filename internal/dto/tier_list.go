@@ -0,0 +1,36 @@
+package dto
+
+type RespTierList struct {
+	Platform int          `json:"platform"`
+	Version  string       `json:"version"`
+	Pos      string       `json:"pos"`
+	Level    int          `json:"level"`
+	Formula  TierFormula  `json:"formula"`
+	Tiers    []*TierGroup `json:"tiers"`
+}
+
+// TierFormula 评分公式：各项指标在同一位置内标准化后按权重求和
+type TierFormula struct {
+	WinWeight   float64 `json:"winWeight"`
+	PickWeight  float64 `json:"pickWeight"`
+	BanWeight   float64 `json:"banWeight"`
+	MinShowRate int     `json:"minShowRate"` // 出场率低于该值（万分比）的英雄样本太少，不参与评级
+}
+
+type TierGroup struct {
+	Tier   string      `json:"tier"` // S/A/B/C/D
+	Heroes []*TierHero `json:"heroes"`
+}
+
+type TierHero struct {
+	HeroId   string  `json:"heroId"`
+	Name     string  `json:"name"`
+	Avatar   string  `json:"avatar"`
+	Pos      string  `json:"pos"`
+	WinRate  float64 `json:"winRate"`  // 百分比
+	ShowRate float64 `json:"showRate"` // 百分比
+	BanRate  float64 `json:"banRate"`  // 百分比
+	Score    float64 `json:"score"`
+
+	StrengthLevel string `json:"strengthLevel,omitempty"` // 上游给出的强度等级，仅LOLM
+}
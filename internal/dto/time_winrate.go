@@ -0,0 +1,21 @@
+package dto
+
+type RespHeroTimeWinrate struct {
+	HeroId  string             `json:"heroId"`
+	Version string             `json:"version"`
+	Phase   string             `json:"phase"` // 所有位置合并后的强势期：early/mid/late
+	Lanes   []*LaneTimeWinrate `json:"lanes"`
+}
+
+type LaneTimeWinrate struct {
+	Pos    string              `json:"pos"`
+	Phase  string              `json:"phase"`
+	Points []*TimeWinratePoint `json:"points"`
+}
+
+type TimeWinratePoint struct {
+	Section     string  `json:"section"`     // 对局时长区间，如 25-30
+	StartMinute int     `json:"startMinute"` // 区间开始的分钟数
+	Games       int32   `json:"games"`
+	WinRate     float64 `json:"winRate"` // 百分比
+}
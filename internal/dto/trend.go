@@ -0,0 +1,41 @@
+package dto
+
+type RespHeroTrend struct {
+	HeroId string         `json:"heroId"`
+	Level  int            `json:"level"`
+	Start  string         `json:"start"`
+	End    string         `json:"end"`
+	Series []*TrendSeries `json:"series"`
+}
+
+type TrendSeries struct {
+	Pos    string        `json:"pos"`
+	Points []*TrendPoint `json:"points"`
+}
+
+type TrendPoint struct {
+	Date     string  `json:"date"`
+	Version  string  `json:"version"`
+	WinRate  float64 `json:"winRate"`  // 百分比
+	ShowRate float64 `json:"showRate"` // 百分比
+	BanRate  float64 `json:"banRate"`  // 百分比
+}
+
+type RespHeroMovers struct {
+	Metric  string   `json:"metric"` // win/pick/ban
+	Level   int      `json:"level"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Risers  []*Mover `json:"risers"`
+	Fallers []*Mover `json:"fallers"`
+}
+
+type Mover struct {
+	HeroId string  `json:"heroId"`
+	Name   string  `json:"name"`
+	Avatar string  `json:"avatar"`
+	Pos    string  `json:"pos"`
+	From   float64 `json:"from"`  // 百分比
+	To     float64 `json:"to"`    // 百分比
+	Delta  float64 `json:"delta"` // 百分点
+}
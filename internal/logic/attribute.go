@@ -12,6 +12,7 @@ import (
 	"whisper/internal/service"
 	"whisper/pkg/context"
 	"whisper/pkg/log"
+	"whisper/pkg/utils"
 )
 
 // HeroAttribute
@@ -134,46 +135,47 @@ func recordHeroRoleAndSpellAndSkin(ctx *context.Context, data *dto.HeroAttribute
 		mainImg = data.Skins[0].MainImg
 	}
 	attr := &model.HeroAttribute{
-		HeroId:              data.Hero.HeroId,
-		Title:               data.Hero.Title,
-		Name:                data.Hero.Name,
-		Alias:               data.Hero.Alias,
-		ShortBio:            data.Hero.ShortBio,
-		Defense:             data.Hero.Defense,
-		Magic:               data.Hero.Magic,
-		Difficulty:          data.Hero.Difficulty,
-		DifficultyL:         data.Hero.DifficultyL,
-		Attack:              data.Hero.Attack,
-		Attackrange:         data.Hero.AttackRange,
-		Attackdamage:        data.Hero.AttackDamage,
-		Attackspeed:         data.Hero.Attackspeed,
-		Attackspeedperlevel: data.Hero.Attackspeedperlevel,
-		Hp:                  data.Hero.Hp,
-		Hpperlevel:          data.Hero.Hpperlevel,
-		Mp:                  data.Hero.Mp,
-		Mpperlevel:          data.Hero.Mpperlevel,
-		Movespeed:           data.Hero.Movespeed,
-		Armor:               data.Hero.Armor,
-		Armorperlevel:       data.Hero.Armorperlevel,
-		Spellblock:          data.Hero.Spellblock,
-		Spellblockperlevel:  data.Hero.Spellblockperlevel,
-		Hpregen:             data.Hero.Hpregen,
-		Hpregenperlevel:     data.Hero.Hpregenperlevel,
-		Mpregen:             data.Hero.Mpregen,
-		Mpregenperlevel:     data.Hero.Mpregenperlevel,
-		Crit:                data.Hero.Crit,
-		Damage:              data.Hero.Damage,
-		Durability:          data.Hero.Durability,
-		Mobility:            data.Hero.Mobility,
-		Avatar:              avatar,
-		MainImg:             mainImg,
-		Highlightprice:      data.Hero.Highlightprice,
-		GoldPrice:           data.Hero.GoldPrice,
-		Couponprice:         data.Hero.Couponprice,
-		IsWeekFree:          data.Hero.IsWeekFree,
-		Platform:            platform,
-		Version:             data.Version,
-		FileTime:            data.FileTime,
+		HeroId:               data.Hero.HeroId,
+		Title:                data.Hero.Title,
+		Name:                 data.Hero.Name,
+		Alias:                data.Hero.Alias,
+		ShortBio:             data.Hero.ShortBio,
+		Defense:              data.Hero.Defense,
+		Magic:                data.Hero.Magic,
+		Difficulty:           data.Hero.Difficulty,
+		DifficultyL:          data.Hero.DifficultyL,
+		Attack:               data.Hero.Attack,
+		Attackrange:          data.Hero.AttackRange,
+		Attackdamage:         data.Hero.AttackDamage,
+		Attackdamageperlevel: data.Hero.AttackDamagePerLevel,
+		Attackspeed:          data.Hero.Attackspeed,
+		Attackspeedperlevel:  data.Hero.Attackspeedperlevel,
+		Hp:                   data.Hero.Hp,
+		Hpperlevel:           data.Hero.Hpperlevel,
+		Mp:                   data.Hero.Mp,
+		Mpperlevel:           data.Hero.Mpperlevel,
+		Movespeed:            data.Hero.Movespeed,
+		Armor:                data.Hero.Armor,
+		Armorperlevel:        data.Hero.Armorperlevel,
+		Spellblock:           data.Hero.Spellblock,
+		Spellblockperlevel:   data.Hero.Spellblockperlevel,
+		Hpregen:              data.Hero.Hpregen,
+		Hpregenperlevel:      data.Hero.Hpregenperlevel,
+		Mpregen:              data.Hero.Mpregen,
+		Mpregenperlevel:      data.Hero.Mpregenperlevel,
+		Crit:                 data.Hero.Crit,
+		Damage:               data.Hero.Damage,
+		Durability:           data.Hero.Durability,
+		Mobility:             data.Hero.Mobility,
+		Avatar:               avatar,
+		MainImg:              mainImg,
+		Highlightprice:       data.Hero.Highlightprice,
+		GoldPrice:            data.Hero.GoldPrice,
+		Couponprice:          data.Hero.Couponprice,
+		IsWeekFree:           data.Hero.IsWeekFree,
+		Platform:             platform,
+		Version:              data.Version,
+		FileTime:             data.FileTime,
 	}
 	ha := dao.NewHeroAttributeDAO()
 	err3 := ha.DeleteAndInsert(map[string]interface{}{
@@ -266,26 +268,32 @@ func recordHeroSkin(ctx *context.Context, data *dto.HeroAttribute, platform int)
 	hrs := make([]*model.HeroSkin, 0, len(data.Skins))
 
 	for _, skin := range data.Skins {
-		if skin.MainImg == "" {
+		// 炫彩没有原画，只有炫彩图
+		if skin.MainImg == "" && skin.ChromaImg == "" {
 			continue
 		}
 		hs := &model.HeroSkin{
-			HeroId:      data.Hero.HeroId,
-			SkinId:      skin.SkinId,
-			HeroName:    skin.HeroName,
-			HeroTitle:   skin.HeroTitle,
-			Name:        skin.Name,
-			IsBase:      skin.IsBase,
-			EmblemsName: skin.EmblemsName,
-			Description: skin.Description,
-			MainImg:     skin.MainImg,
-			IconImg:     skin.IconImg,
-			LoadingImg:  skin.LoadingImg,
-			VideoImg:    skin.VideoImg,
-			SourceImg:   skin.SourceImg,
-			Platform:    platform,
-			Version:     data.Version,
-			FileTime:    data.FileTime,
+			HeroId:          data.Hero.HeroId,
+			SkinId:          skin.SkinId,
+			HeroName:        skin.HeroName,
+			HeroTitle:       skin.HeroTitle,
+			Name:            skin.Name,
+			IsBase:          skin.IsBase,
+			EmblemsName:     skin.EmblemsName,
+			Description:     skin.Description,
+			MainImg:         skin.MainImg,
+			IconImg:         skin.IconImg,
+			LoadingImg:      skin.LoadingImg,
+			VideoImg:        skin.VideoImg,
+			SourceImg:       skin.SourceImg,
+			ChromaImg:       skin.ChromaImg,
+			Chromas:         skin.Chromas,
+			ChromasBelongId: skin.ChromasBelongId,
+			SuitType:        skin.SuitType,
+			PublishTime:     skin.PublishTime,
+			Platform:        platform,
+			Version:         data.Version,
+			FileTime:        data.FileTime,
 		}
 
 		hrs = append(hrs, hs)
@@ -361,21 +369,30 @@ func GetAllHeroesFromAttr(ctx *context.Context, platform []int) ([]*model.HeroAt
 	})
 }
 
-func GetAttribute(ctx *context.Context, platform int, heroID string) (*model.HeroAttribute, error) {
-	ad := dao.NewHeroAttributeDAO()
-	ret, err := ad.Find([]string{
-		"heroId", "title", "name", "alias", "shortBio", "defense", "magic", "difficulty", "difficultyL", "attack", "attackrange", "attackdamage", "attackspeed", "attackspeedperlevel", "hp", "hpperlevel", "mp", "mpperlevel", "movespeed", "armor", "armorperlevel", "spellblock", "spellblockperlevel", "hpregen", "hpregenperlevel", "mpregen", "mpregenperlevel", "crit", "damage", "durability", "mobility", "avatar", "highlightprice", "goldPrice", "couponprice", "isWeekFree", "platform", "version", "fileTime", "ctime", "utime",
-	}, map[string]interface{}{
+// GetAttribute 获取英雄属性，version为空时取库中最新的版本
+func GetAttribute(ctx *context.Context, platform int, heroID string, version string) (*model.HeroAttribute, error) {
+	cond := map[string]interface{}{
 		"platform": platform,
 		"heroId":   heroID,
-	})
+	}
+	if version != "" {
+		cond["version"] = version
+	}
+
+	ad := dao.NewHeroAttributeDAO()
+	ret, err := ad.Find([]string{
+		"heroId", "title", "name", "alias", "shortBio", "defense", "magic", "difficulty", "difficultyL", "attack", "attackrange", "attackdamage", "attackdamageperlevel", "attackspeed", "attackspeedperlevel", "hp", "hpperlevel", "mp", "mpperlevel", "movespeed", "armor", "armorperlevel", "spellblock", "spellblockperlevel", "hpregen", "hpregenperlevel", "mpregen", "mpregenperlevel", "crit", "damage", "durability", "mobility", "avatar", "highlightprice", "goldPrice", "couponprice", "isWeekFree", "platform", "version", "fileTime", "ctime", "utime",
+	}, cond)
 	if err != nil {
 		return nil, err
 	}
-	if len(ret) > 0 {
-		return ret[0], nil
+	var attr *model.HeroAttribute
+	for _, r := range ret {
+		if attr == nil || utils.CompareVersion(r.Version, attr.Version) > 0 {
+			attr = r
+		}
 	}
-	return nil, nil
+	return attr, nil
 }
 
 // AttrData2Redis todo 未完成
@@ -0,0 +1,19 @@
+package logic
+
+import (
+	"whisper/pkg/context"
+	"whisper/pkg/redis"
+)
+
+// CacheStats 按代管理的缓存的当前代、各代key数量和命中情况
+func CacheStats(ctx *context.Context) ([]*redis.GenerationStats, error) {
+	result := make([]*redis.GenerationStats, 0, len(redis.CacheNamespaces))
+	for _, ns := range redis.CacheNamespaces {
+		stats, err := redis.Stats(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package common
+
+// 暴击的基础伤害为 175%
+const BaseCritDamage = 175
+
+// EffectiveResist 计算护甲/魔抗在减免和穿透后的有效值
+// 顺序：固定值减少 -> 百分比减少 -> 百分比穿透 -> 固定值穿透
+// 减少可以让护甲变成负数，穿透最多只能把护甲穿到0
+func EffectiveResist(resist, reduction, reductionRate, peneRate, pene float64) float64 {
+	r := resist - reduction
+	if r > 0 {
+		r *= 1 - reductionRate/100
+	}
+	if r <= 0 {
+		return r
+	}
+	r *= 1 - peneRate/100
+	r -= pene
+	if r < 0 {
+		r = 0
+	}
+	return r
+}
+
+// DamageMultiplier 护甲/魔抗对伤害的乘数
+func DamageMultiplier(resist float64) float64 {
+	if resist >= 0 {
+		return 100 / (100 + resist)
+	}
+	return 2 - 100/(100-resist)
+}
+
+// EffectiveHealth 有效生命值
+func EffectiveHealth(hp, resist float64) float64 {
+	return hp / DamageMultiplier(resist)
+}
+
+// ExpectedCritMultiplier 计算暴击期望后的伤害倍数，critRate、critDamage 均为百分数
+func ExpectedCritMultiplier(critRate, critDamage float64) float64 {
+	if critRate > 100 {
+		critRate = 100
+	}
+	return 1 + critRate/100*(critDamage-100)/100
+}
@@ -0,0 +1,60 @@
+package common
+
+import "testing"
+
+func TestEffectiveResist(t *testing.T) {
+	cases := []struct {
+		name                                     string
+		resist, reduction, reductionRate, peRate float64
+		pene                                     float64
+		want                                     float64
+	}{
+		{"no change", 100, 0, 0, 0, 0, 100},
+		{"flat pene", 100, 0, 0, 0, 18, 82},
+		// 100 * (1-30%) * (1-35%) - 10
+		{"all kinds", 120, 20, 30, 35, 10, 35.5},
+		{"pene stops at zero", 20, 0, 0, 0, 30, 0},
+		{"reduction can go negative", 20, 30, 0, 35, 10, -10},
+	}
+	for _, c := range cases {
+		if got := EffectiveResist(c.resist, c.reduction, c.reductionRate, c.peRate, c.pene); !almostEqual(got, c.want) {
+			t.Errorf("%s: EffectiveResist = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDamageMultiplier(t *testing.T) {
+	cases := []struct {
+		resist float64
+		want   float64
+	}{
+		{0, 1},
+		{100, 0.5},
+		{-100, 1.5},
+	}
+	for _, c := range cases {
+		if got := DamageMultiplier(c.resist); !almostEqual(got, c.want) {
+			t.Errorf("DamageMultiplier(%v) = %v, want %v", c.resist, got, c.want)
+		}
+	}
+	if got := EffectiveHealth(1000, 100); !almostEqual(got, 2000) {
+		t.Errorf("EffectiveHealth = %v, want 2000", got)
+	}
+}
+
+func TestExpectedCritMultiplier(t *testing.T) {
+	cases := []struct {
+		rate, damage float64
+		want         float64
+	}{
+		{0, BaseCritDamage, 1},
+		{100, BaseCritDamage, 1.75},
+		{50, 215, 1.575},
+		{150, BaseCritDamage, 1.75},
+	}
+	for _, c := range cases {
+		if got := ExpectedCritMultiplier(c.rate, c.damage); !almostEqual(got, c.want) {
+			t.Errorf("ExpectedCritMultiplier(%v, %v) = %v, want %v", c.rate, c.damage, got, c.want)
+		}
+	}
+}
@@ -4,3 +4,27 @@ const (
 	MaxHeroIDForLOL  = 950   // select max(CAST(heroId AS SIGNED)) from lol_heroes;
 	MinHeroIDForLOLM = 10001 // select min(CAST(heroId AS SIGNED)) from lolm_heroes;
 )
+
+const (
+	MinHeroLevel = 1
+	MaxHeroLevel = 18
+)
+
+// StatGrowth 英雄属性在 level 级时的成长值
+// 官方公式：growth * (level-1) * (0.7025 + 0.0175 * (level-1))
+func StatGrowth(growth float64, level int) float64 {
+	n := float64(level - 1)
+	return growth * n * (0.7025 + 0.0175*n)
+}
+
+// StatAtLevel 英雄属性在 level 级时的值
+func StatAtLevel(base, growth float64, level int) float64 {
+	return base + StatGrowth(growth, level)
+}
+
+// AttackSpeedAtLevel 攻速在 level 级时的值
+// 成长和额外的攻速是百分比加成，官方公式乘的是攻速系数。上游数据没有攻速系数，这里用基础攻速代替，
+// 对攻速系数与基础攻速不同的英雄（如 0.658 基础攻速、0.625 系数）结果会偏高
+func AttackSpeedAtLevel(base, growthPercent, bonusPercent float64, level int) float64 {
+	return base + base*(StatGrowth(growthPercent, level)+bonusPercent)/100
+}
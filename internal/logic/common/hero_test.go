@@ -0,0 +1,56 @@
+package common
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestStatGrowth(t *testing.T) {
+	cases := []struct {
+		growth float64
+		level  int
+		want   float64
+	}{
+		{100, 1, 0},
+		// 1 * (0.7025 + 0.0175)
+		{100, 2, 72},
+		// 18级时刚好是 17 倍成长值
+		{100, 18, 1700},
+		{0, 10, 0},
+	}
+	for _, c := range cases {
+		if got := StatGrowth(c.growth, c.level); !almostEqual(got, c.want) {
+			t.Errorf("StatGrowth(%v, %d) = %v, want %v", c.growth, c.level, got, c.want)
+		}
+	}
+}
+
+func TestStatAtLevel(t *testing.T) {
+	if got := StatAtLevel(600, 100, 18); !almostEqual(got, 2300) {
+		t.Errorf("StatAtLevel = %v, want 2300", got)
+	}
+}
+
+func TestAttackSpeedAtLevel(t *testing.T) {
+	cases := []struct {
+		name         string
+		base, growth float64
+		bonus        float64
+		level        int
+		want         float64
+	}{
+		{"level 1", 0.625, 3, 0, 1, 0.625},
+		// 攻速成长按基础攻速计算：0.625 + 0.625 * 51%
+		{"level 18", 0.625, 3, 0, 18, 0.625 + 0.625*0.51},
+		{"with bonus", 0.658, 0, 40, 1, 0.658 + 0.658*0.4},
+	}
+	for _, c := range cases {
+		if got := AttackSpeedAtLevel(c.base, c.growth, c.bonus, c.level); !almostEqual(got, c.want) {
+			t.Errorf("%s: AttackSpeedAtLevel = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
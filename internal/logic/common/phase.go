@@ -0,0 +1,35 @@
+package common
+
+// 英雄强势期
+const (
+	GamePhaseEarly = "early"
+	GamePhaseMid   = "mid"
+	GamePhaseLate  = "late"
+)
+
+// 强势期按对局时长划分，单位：分钟
+const (
+	GamePhaseMidMinute  = 25
+	GamePhaseLateMinute = 35
+)
+
+// GamePhaseMinSpread 各时期胜率（万分比）相差不到这个值时，认为英雄没有明显的强势期，归为中期
+const GamePhaseMinSpread = 100
+
+var GamePhaseTag = map[string]string{
+	GamePhaseEarly: "前期强势",
+	GamePhaseMid:   "中期发力",
+	GamePhaseLate:  "后期大核",
+}
+
+// GamePhaseOf 对局时间所属的时期
+func GamePhaseOf(minute int) string {
+	switch {
+	case minute < GamePhaseMidMinute:
+		return GamePhaseEarly
+	case minute < GamePhaseLateMinute:
+		return GamePhaseMid
+	default:
+		return GamePhaseLate
+	}
+}
@@ -0,0 +1,22 @@
+package common
+
+import "testing"
+
+func TestGamePhaseOf(t *testing.T) {
+	cases := []struct {
+		minute int
+		want   string
+	}{
+		{0, GamePhaseEarly},
+		{GamePhaseMidMinute - 1, GamePhaseEarly},
+		{GamePhaseMidMinute, GamePhaseMid},
+		{GamePhaseLateMinute - 1, GamePhaseMid},
+		{GamePhaseLateMinute, GamePhaseLate},
+		{60, GamePhaseLate},
+	}
+	for _, c := range cases {
+		if got := GamePhaseOf(c.minute); got != c.want {
+			t.Errorf("GamePhaseOf(%d) = %s, want %s", c.minute, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+package logic
+
+import (
+	errors2 "errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+	"whisper/pkg/utils"
+)
+
+// 对比的实体类型
+const (
+	CompareTypeEquip = "equip"
+	CompareTypeHero  = "hero"
+	CompareTypeRune  = "rune"
+	CompareTypeSkill = "skill"
+)
+
+// CompareParams 需要对比的实体，version为空时取最新版本
+type CompareParams struct {
+	ID       string
+	Version  string
+	Platform int
+}
+
+// compareKV 有序的字段
+type compareKV struct {
+	Key   string
+	Value interface{}
+}
+
+type compareRow struct {
+	entity *dto.CompareEntity
+	fields []compareKV
+}
+
+// Compare 对比多个同类型的实体，返回字段对齐后的矩阵
+func Compare(ctx *context.Context, typ string, params []*CompareParams) (*dto.RespCompare, error) {
+	rows := make([]*compareRow, 0, len(params))
+	for _, p := range params {
+		var (
+			row *compareRow
+			err error
+		)
+		switch typ {
+		case CompareTypeEquip:
+			row, err = compareEquip(p)
+		case CompareTypeHero:
+			row, err = compareHero(ctx, p)
+		case CompareTypeRune:
+			row, err = compareRune(p)
+		case CompareTypeSkill:
+			row, err = compareSkill(p)
+		default:
+			return nil, errors2.New("unknown compare type " + typ)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			return nil, fmt.Errorf("can not find %s %s", typ, p.ID)
+		}
+		rows = append(rows, row)
+	}
+
+	resp := &dto.RespCompare{
+		Type:     typ,
+		Entities: make([]*dto.CompareEntity, 0, len(rows)),
+		Fields:   compareFields(rows),
+	}
+	for _, row := range rows {
+		resp.Entities = append(resp.Entities, row.entity)
+	}
+
+	log.Logger.Info(ctx, fmt.Sprintf("compare type:%s count:%d fields:%d", typ, len(rows), len(resp.Fields)))
+	return resp, nil
+}
+
+// compareFields 按出现顺序合并所有字段，缺失的字段填 nil
+func compareFields(rows []*compareRow) []*dto.CompareField {
+	result := make([]*dto.CompareField, 0)
+	keys := make([]string, 0)
+	exists := make(map[string]bool)
+	values := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		v := make(map[string]interface{}, len(row.fields))
+		for _, kv := range row.fields {
+			v[kv.Key] = kv.Value
+			if !exists[kv.Key] {
+				exists[kv.Key] = true
+				keys = append(keys, kv.Key)
+			}
+		}
+		values = append(values, v)
+	}
+
+	for _, key := range keys {
+		field := &dto.CompareField{
+			Field:  key,
+			Values: make([]interface{}, 0, len(values)),
+		}
+		numeric := true
+		nums := make([]float64, 0, len(values))
+		for _, v := range values {
+			field.Values = append(field.Values, v[key])
+			n, ok := v[key].(float64)
+			if !ok {
+				numeric = false
+			}
+			nums = append(nums, n)
+			if fmt.Sprint(v[key]) != fmt.Sprint(values[0][key]) {
+				field.Diff = true
+			}
+		}
+		if numeric {
+			field.Delta = make([]float64, 0, len(nums))
+			for _, n := range nums {
+				field.Delta = append(field.Delta, n-nums[0])
+			}
+		}
+		field.BestIdx = compareBestIdx(key, nums, numeric && field.Diff)
+		result = append(result, field)
+	}
+	return result
+}
+
+// compareLowerBetter 数值越小越好的字段，其余数值字段越大越好
+var compareLowerBetter = map[string]bool{
+	"price":         true,
+	"goldPrice":     true,
+	"couponprice":   true,
+	"difficulty":    true,
+	"cooldown":      true,
+	"cd":            true,
+	"unlockLv":      true,
+	"unlocklv":      true,
+	"summonerlevel": true,
+}
+
+// compareBestIdx 最优值的下标，不可比较或各实体的值相同时为 -1
+func compareBestIdx(key string, nums []float64, comparable bool) int {
+	if !comparable || len(nums) == 0 {
+		return -1
+	}
+	best := 0
+	for i, n := range nums {
+		if (compareLowerBetter[key] && n < nums[best]) || (!compareLowerBetter[key] && n > nums[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// compareNumber 能转成数字的字段按数字对比
+func compareNumber(s string) interface{} {
+	if f, err := cast.ToFloat64E(s); err == nil {
+		return f
+	}
+	return s
+}
+
+func compareEquip(p *CompareParams) (*compareRow, error) {
+	var item *roadmapItem
+	if p.Platform == common.PlatformForLOL {
+		cond := map[string]interface{}{"itemId": p.ID, "status": 0}
+		if p.Version != "" {
+			cond["version"] = p.Version
+		}
+		equips, err := dao.NewLOLEquipmentDAO().Find(nil, cond)
+		if err != nil || len(equips) == 0 {
+			return nil, err
+		}
+		e := equips[0]
+		for _, equip := range equips {
+			if utils.CompareVersion(equip.Version, e.Version) > 0 {
+				e = equip
+			}
+		}
+		// 按地图存储的多行合并地图
+		maps := make([]string, 0)
+		for _, equip := range equips {
+			if equip.Version == e.Version && !inArray(equip.Maps, maps) {
+				maps = append(maps, equip.Maps)
+			}
+		}
+		item = &roadmapItem{
+			Roadmap: dto.Roadmap{
+				Name:      e.Name,
+				Icon:      e.IconPath,
+				Maps:      strings.Join(maps, ","),
+				Plaintext: e.Plaintext,
+				Desc:      e.Description,
+				Price:     cast.ToInt(e.Total),
+				Sell:      cast.ToInt(e.Sell),
+				Version:   e.Version,
+			},
+			Stats: ParseLOLEquipStats(e.Description),
+		}
+	} else {
+		cond := map[string]interface{}{"equipId": p.ID, "status": 0}
+		if p.Version != "" {
+			cond["version"] = p.Version
+		}
+		equips, err := dao.NewLOLMEquipmentDAO().Find(nil, cond)
+		if err != nil || len(equips) == 0 {
+			return nil, err
+		}
+		e := equips[0]
+		for _, equip := range equips {
+			if utils.CompareVersion(equip.Version, e.Version) > 0 {
+				e = equip
+			}
+		}
+		item = &roadmapItem{
+			Roadmap: dto.Roadmap{
+				Name:    e.Name,
+				Icon:    e.IconPath,
+				Level:   e.Level,
+				Desc:    e.Description,
+				Price:   cast.ToInt(e.Price),
+				Version: e.Version,
+			},
+			Stats: LOLMEquipStats(e),
+		}
+	}
+
+	row := &compareRow{
+		entity: &dto.CompareEntity{
+			ID:       p.ID,
+			Name:     item.Name,
+			Icon:     item.Icon,
+			Version:  item.Version,
+			Platform: p.Platform,
+		},
+		fields: []compareKV{
+			{"name", item.Name},
+			{"price", float64(item.Price)},
+			{"sell", float64(item.Sell)},
+			{"maps", item.Maps},
+			{"level", item.Level},
+			{"plaintext", item.Plaintext},
+			{"desc", item.Desc},
+		},
+	}
+	for _, key := range EquipStatKeys {
+		if v, ok := item.Stats[key]; ok {
+			row.fields = append(row.fields, compareKV{"stats." + key, v})
+		}
+	}
+	return row, nil
+}
+
+func compareHero(ctx *context.Context, p *CompareParams) (*compareRow, error) {
+	attr, err := GetAttribute(ctx, p.Platform, p.ID, p.Version)
+	if err != nil || attr == nil {
+		return nil, err
+	}
+	return &compareRow{
+		entity: &dto.CompareEntity{
+			ID:       p.ID,
+			Name:     attr.Name,
+			Icon:     attr.Avatar,
+			Version:  attr.Version,
+			Platform: p.Platform,
+		},
+		fields: []compareKV{
+			{"name", attr.Name},
+			{"title", attr.Title},
+			{"attack", compareNumber(attr.Attack)},
+			{"defense", compareNumber(attr.Defense)},
+			{"magic", compareNumber(attr.Magic)},
+			{"difficulty", compareNumber(attr.Difficulty)},
+			{"hp", compareNumber(attr.Hp)},
+			{"hpperlevel", compareNumber(attr.Hpperlevel)},
+			{"mp", compareNumber(attr.Mp)},
+			{"mpperlevel", compareNumber(attr.Mpperlevel)},
+			{"attackdamage", compareNumber(attr.Attackdamage)},
+			{"attackdamageperlevel", compareNumber(attr.Attackdamageperlevel)},
+			{"attackspeed", compareNumber(attr.Attackspeed)},
+			{"attackspeedperlevel", compareNumber(attr.Attackspeedperlevel)},
+			{"attackrange", compareNumber(attr.Attackrange)},
+			{"armor", compareNumber(attr.Armor)},
+			{"armorperlevel", compareNumber(attr.Armorperlevel)},
+			{"spellblock", compareNumber(attr.Spellblock)},
+			{"spellblockperlevel", compareNumber(attr.Spellblockperlevel)},
+			{"hpregen", compareNumber(attr.Hpregen)},
+			{"hpregenperlevel", compareNumber(attr.Hpregenperlevel)},
+			{"mpregen", compareNumber(attr.Mpregen)},
+			{"mpregenperlevel", compareNumber(attr.Mpregenperlevel)},
+			{"crit", compareNumber(attr.Crit)},
+			{"movespeed", compareNumber(attr.Movespeed)},
+			{"goldPrice", compareNumber(attr.GoldPrice)},
+			{"couponprice", compareNumber(attr.Couponprice)},
+		},
+	}, nil
+}
+
+func compareRune(p *CompareParams) (*compareRow, error) {
+	if p.Platform == common.PlatformForLOL {
+		cond := map[string]interface{}{"rune_id": p.ID, "status": 0}
+		if p.Version != "" {
+			cond["version"] = p.Version
+		}
+		runes, err := dao.NewLOLRuneDAO().Find(nil, cond)
+		if err != nil || len(runes) == 0 {
+			return nil, err
+		}
+		r := runes[0]
+		for _, item := range runes {
+			if utils.CompareVersion(item.Version, r.Version) > 0 {
+				r = item
+			}
+		}
+		return &compareRow{
+			entity: &dto.CompareEntity{ID: p.ID, Name: r.Name, Icon: r.Icon, Version: r.Version, Platform: p.Platform},
+			fields: []compareKV{
+				{"name", r.Name},
+				{"styleName", r.StyleName},
+				{"slotLabel", r.SlotLabel},
+				{"shortdesc", r.Shortdesc},
+				{"longdesc", r.Longdesc},
+				{"tooltip", r.Tooltip},
+			},
+		}, nil
+	}
+
+	cond := map[string]interface{}{"runeId": p.ID, "status": 0}
+	if p.Version != "" {
+		cond["version"] = p.Version
+	}
+	runes, err := dao.NewLOLMRuneDAO().Find(nil, cond)
+	if err != nil || len(runes) == 0 {
+		return nil, err
+	}
+	r := runes[0]
+	for _, item := range runes {
+		if utils.CompareVersion(item.Version, r.Version) > 0 {
+			r = item
+		}
+	}
+	return &compareRow{
+		entity: &dto.CompareEntity{ID: p.ID, Name: r.Name, Icon: r.IconPath, Version: r.Version, Platform: p.Platform},
+		fields: []compareKV{
+			{"name", r.Name},
+			{"type", r.Type},
+			{"styleName", r.StyleName},
+			{"attrName", r.AttrName},
+			{"unlockLv", compareNumber(r.UnlockLv)},
+			{"description", r.Description},
+			{"detailInfo", r.DetailInfo},
+		},
+	}, nil
+}
+
+func compareSkill(p *CompareParams) (*compareRow, error) {
+	if p.Platform == common.PlatformForLOL {
+		cond := map[string]interface{}{"skill_id": p.ID, "status": 0}
+		if p.Version != "" {
+			cond["version"] = p.Version
+		}
+		skills, err := dao.NewLOLSkillDAO().Find(nil, cond)
+		if err != nil || len(skills) == 0 {
+			return nil, err
+		}
+		s := skills[0]
+		for _, item := range skills {
+			if utils.CompareVersion(item.Version, s.Version) > 0 {
+				s = item
+			}
+		}
+		return &compareRow{
+			entity: &dto.CompareEntity{ID: p.ID, Name: s.Name, Icon: s.Icon, Version: s.Version, Platform: p.Platform},
+			fields: []compareKV{
+				{"name", s.Name},
+				{"cooldown", compareNumber(s.Cooldown)},
+				{"summonerlevel", compareNumber(s.Summonerlevel)},
+				{"gamemode", s.Gamemode},
+				{"description", s.Description},
+			},
+		}, nil
+	}
+
+	cond := map[string]interface{}{"skillId": p.ID, "status": 0}
+	if p.Version != "" {
+		cond["version"] = p.Version
+	}
+	skills, err := dao.NewLOLMSkillDAO().Find(nil, cond)
+	if err != nil || len(skills) == 0 {
+		return nil, err
+	}
+	s := skills[0]
+	for _, item := range skills {
+		if utils.CompareVersion(item.Version, s.Version) > 0 {
+			s = item
+		}
+	}
+	return &compareRow{
+		entity: &dto.CompareEntity{ID: p.ID, Name: s.Name, Icon: s.IconPath, Version: s.Version, Platform: p.Platform},
+		fields: []compareKV{
+			{"name", s.Name},
+			{"cd", compareNumber(s.Cd)},
+			{"unlocklv", compareNumber(s.Unlocklv)},
+			{"mode", s.Mode},
+			{"funcDesc", s.FuncDesc},
+		},
+	}, nil
+}
@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareNumber(t *testing.T) {
+	cases := []struct {
+		in   string
+		want interface{}
+	}{
+		{"10", float64(10)},
+		{"0.625", 0.625},
+		{"", ""},
+		{"近战", "近战"},
+	}
+	for _, c := range cases {
+		if got := compareNumber(c.in); got != c.want {
+			t.Errorf("compareNumber(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestCompareBestIdx(t *testing.T) {
+	cases := []struct {
+		key        string
+		nums       []float64
+		comparable bool
+		want       int
+	}{
+		{"stats.ad", []float64{40, 60, 55}, true, 1},
+		{"price", []float64{3000, 2800, 3100}, true, 1},
+		{"cd", []float64{180, 300}, true, 0},
+		// 第一个实体最优时为 0，不能和没有最优值混淆
+		{"armor", []float64{50, 30}, true, 0},
+		{"armor", []float64{30, 30}, false, -1},
+		{"name", []float64{0, 0}, false, -1},
+	}
+	for _, c := range cases {
+		if got := compareBestIdx(c.key, c.nums, c.comparable); got != c.want {
+			t.Errorf("compareBestIdx(%s, %v) = %d, want %d", c.key, c.nums, got, c.want)
+		}
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	rows := []*compareRow{
+		{fields: []compareKV{{"name", "a"}, {"price", float64(3000)}, {"stats.ad", float64(40)}}},
+		{fields: []compareKV{{"name", "b"}, {"price", float64(2800)}, {"stats.ap", float64(80)}}},
+	}
+	fields := compareFields(rows)
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.Field)
+	}
+	if !reflect.DeepEqual(keys, []string{"name", "price", "stats.ad", "stats.ap"}) {
+		t.Fatalf("keys = %v", keys)
+	}
+
+	price := fields[1]
+	if !price.Diff || price.BestIdx != 1 || !reflect.DeepEqual(price.Delta, []float64{0, -200}) {
+		t.Errorf("price = %+v", price)
+	}
+	// 缺失的字段填 nil，不参与最优值比较
+	ad := fields[2]
+	if ad.Values[1] != nil || ad.Delta != nil || ad.BestIdx != -1 {
+		t.Errorf("stats.ad = %+v", ad)
+	}
+	if name := fields[0]; !name.Diff || name.BestIdx != -1 {
+		t.Errorf("name = %+v", name)
+	}
+}
@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"math"
+	"whisper/internal/model"
+)
+
+const (
+	// wilsonZ 95%置信度
+	wilsonZ = 1.96
+	// MinSampleGames 样本数低于该值时标记为样本少
+	MinSampleGames = 100
+)
+
+// wilsonLowerBound 胜率的Wilson下界（万分比），样本越少下界越低
+// 3场全胜约为43.85%，3万场55%约为54.44%
+func wilsonLowerBound(wins, games int32) int32 {
+	if games <= 0 {
+		return 0
+	}
+	n := float64(games)
+	p := math.Min(float64(wins)/n, 1)
+	z2 := wilsonZ * wilsonZ
+	center := p + z2/(2*n)
+	margin := wilsonZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return int32(math.Round((center - margin) / (1 + z2/n) * 10000))
+}
+
+// isLowSample 没有统计数据的推荐（如手游的主播推荐）不算样本少
+func isLowSample(games int32) bool {
+	return games > 0 && games < MinSampleGames
+}
+
+// suitWins 出装的胜场，上游没有给出胜场时按胜率换算
+func suitWins(row *model.HeroesSuit) int32 {
+	if row.Wincnt == 0 && row.Winrate > 0 {
+		return int32(math.Round(float64(row.Winrate) * float64(row.Igamecnt) / 10000))
+	}
+	return row.Wincnt
+}
+
+// suitScore 出装的置信分
+func suitScore(row *model.HeroesSuit) int32 {
+	return wilsonLowerBound(suitWins(row), row.Igamecnt)
+}
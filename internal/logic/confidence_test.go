@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/model"
+)
+
+func TestWilsonLowerBound(t *testing.T) {
+	cases := []struct {
+		wins, games int32
+		want        int32
+	}{
+		{0, 0, 0},
+		{5, -1, 0},
+		{3, 3, 4385},
+		{16500, 30000, 5444},
+		{0, 10, 0},
+		// 胜场大于场次时按全胜计算
+		{5, 3, 4385},
+	}
+	for _, c := range cases {
+		if got := wilsonLowerBound(c.wins, c.games); got != c.want {
+			t.Errorf("wilsonLowerBound(%d, %d) = %d, want %d", c.wins, c.games, got, c.want)
+		}
+	}
+
+	// 胜率相同时样本多的下界更高
+	if wilsonLowerBound(60, 100) >= wilsonLowerBound(600, 1000) {
+		t.Errorf("lower bound should grow with the sample size")
+	}
+}
+
+func TestIsLowSample(t *testing.T) {
+	cases := []struct {
+		games int32
+		want  bool
+	}{
+		{0, false},
+		{1, true},
+		{MinSampleGames - 1, true},
+		{MinSampleGames, false},
+	}
+	for _, c := range cases {
+		if got := isLowSample(c.games); got != c.want {
+			t.Errorf("isLowSample(%d) = %v, want %v", c.games, got, c.want)
+		}
+	}
+}
+
+func TestSuitScore(t *testing.T) {
+	cases := []struct {
+		name string
+		row  *model.HeroesSuit
+		wins int32
+	}{
+		{"wincnt", &model.HeroesSuit{Igamecnt: 200, Wincnt: 120, Winrate: 5000}, 120},
+		// 上游没有胜场时按胜率换算
+		{"winrate", &model.HeroesSuit{Igamecnt: 200, Winrate: 5525}, 111},
+		{"empty", &model.HeroesSuit{}, 0},
+	}
+	for _, c := range cases {
+		if got := suitWins(c.row); got != c.wins {
+			t.Errorf("%s: suitWins = %d, want %d", c.name, got, c.wins)
+		}
+		if got, want := suitScore(c.row), wilsonLowerBound(c.wins, c.row.Igamecnt); got != want {
+			t.Errorf("%s: suitScore = %d, want %d", c.name, got, want)
+		}
+	}
+}
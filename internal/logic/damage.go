@@ -0,0 +1,233 @@
+package logic
+
+import (
+	errors2 "errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"math"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// MaxAttackSpeed 攻速上限
+const MaxAttackSpeed = 2.5
+
+// DamageParams 伤害计算参数
+type DamageParams struct {
+	Platform int
+	HeroID   string
+	Version  string
+	Level    int
+	Items    []string
+	Runes    []string
+	Target   *DamageTargetParams
+}
+
+// DamageTargetParams 目标可以直接指定护甲/魔抗/生命值，也可以指定英雄和等级
+type DamageTargetParams struct {
+	HeroID     string
+	Level      int
+	Hp         float64
+	Armor      float64
+	MagicBlock float64
+
+	// 技能等带来的护甲/魔抗减少
+	ArmorReduction     float64
+	ArmorReductionRate float64
+	MagicReduction     float64
+	MagicReductionRate float64
+}
+
+// DamageCalc 计算英雄在指定等级、出装和符文下的攻防属性，以及对目标的普攻伤害
+func DamageCalc(ctx *context.Context, params *DamageParams) (*dto.RespDamageCalc, error) {
+	if params.Level < common.MinHeroLevel || params.Level > common.MaxHeroLevel {
+		return nil, errors2.New("level out of range")
+	}
+	attr, err := GetAttribute(ctx, params.Platform, params.HeroID, params.Version)
+	if err != nil {
+		return nil, err
+	}
+	if attr == nil {
+		return nil, errors2.New("can not find hero " + params.HeroID)
+	}
+	base := heroStatsAtLevel(attr, params.Level)
+
+	bonus, err := damageBonusStats(params.Platform, params.Version, params.Items, params.Runes)
+	if err != nil {
+		return nil, err
+	}
+	applyAdaptive(bonus)
+
+	as := common.AttackSpeedAtLevel(cast.ToFloat64(attr.Attackspeed), cast.ToFloat64(attr.Attackspeedperlevel), bonus[StatAttackSpeed], params.Level)
+	offense := &dto.DamageOffense{
+		AD:            round2(base.Attackdamage + bonus[StatAD]),
+		AP:            round2(bonus[StatAP]),
+		AttackSpeed:   round3(math.Min(as, MaxAttackSpeed)),
+		CritRate:      math.Min(base.Crit+bonus[StatCritRate], 100),
+		CritDamage:    common.BaseCritDamage + bonus[StatCritDamage],
+		ArmorPene:     bonus[StatArmorPene],
+		ArmorPeneRate: bonus[StatArmorPeneRate],
+		MagicPene:     bonus[StatMagicPene],
+		MagicPeneRate: bonus[StatMagicPeneRate],
+	}
+
+	hp := base.Hp + bonus[StatHP]
+	armor := base.Armor + bonus[StatArmor]
+	mr := base.Spellblock + bonus[StatMagicBlock]
+	defense := &dto.DamageDefense{
+		Hp:          round2(hp),
+		Armor:       round2(armor),
+		MagicBlock:  round2(mr),
+		EHPPhysical: round2(common.EffectiveHealth(hp, armor)),
+		EHPMagic:    round2(common.EffectiveHealth(hp, mr)),
+	}
+
+	target, err := damageTarget(ctx, params, offense)
+	if err != nil {
+		return nil, err
+	}
+
+	perAttack := offense.AD * common.ExpectedCritMultiplier(offense.CritRate, offense.CritDamage) * target.PhysicalMultiplier
+	resp := &dto.RespDamageCalc{
+		Hero:            base,
+		Bonus:           bonus,
+		Offense:         offense,
+		Defense:         defense,
+		Target:          target,
+		DamagePerAttack: round2(perAttack),
+		DPS:             round2(perAttack * offense.AttackSpeed),
+	}
+
+	log.Logger.Info(ctx, fmt.Sprintf("damage calc hero:%s level:%d items:%v runes:%v per attack:%f", params.HeroID, params.Level, params.Items, params.Runes, resp.DamagePerAttack))
+	return resp, nil
+}
+
+// applyAdaptive 适应之力：额外法强高于额外攻击力时转为法强，否则转为攻击力
+func applyAdaptive(bonus map[string]float64) {
+	adaptive := bonus[StatAdaptive]
+	delete(bonus, StatAdaptive)
+	if adaptive <= 0 {
+		return
+	}
+	if bonus[StatAP] > bonus[StatAD] {
+		bonus[StatAP] += adaptive
+	} else {
+		bonus[StatAD] += adaptive * 0.6
+	}
+}
+
+// damageBonusStats 汇总装备和符文的属性，version 与英雄一致，为空时取各自的最新版本
+func damageBonusStats(platform int, version string, itemIDs, runeIDs []string) (map[string]float64, error) {
+	bonus := make(map[string]float64)
+	if len(itemIDs) > 0 {
+		equipVersion := version
+		if equipVersion == "" {
+			v, err := currentEquipVersion(platform)
+			if err != nil {
+				return nil, err
+			}
+			equipVersion = v
+		}
+		items, err := loadRoadmapItems(platform, equipVersion, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range itemIDs {
+			item, ok := items[id]
+			if !ok {
+				return nil, errors2.New("can not find equip " + id)
+			}
+			for key, v := range item.Stats {
+				bonus[key] += v
+			}
+		}
+	}
+
+	if len(runeIDs) == 0 {
+		return bonus, nil
+	}
+	if platform == common.PlatformForLOL {
+		rd := dao.NewLOLRuneDAO()
+		if version == "" {
+			v, err := rd.GetLOLRuneMaxVersion()
+			if err != nil || v == nil {
+				return bonus, err
+			}
+			version = v.Version
+		}
+		runes, err := rd.Find(nil, map[string]interface{}{
+			"rune_id": runeIDs,
+			"version": version,
+			"status":  0,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runes {
+			for key, val := range ParseRuneStats(r.Shortdesc) {
+				bonus[key] += val
+			}
+		}
+		return bonus, nil
+	}
+
+	rd := dao.NewLOLMRuneDAO()
+	if version == "" {
+		v, err := rd.GetLOLMRuneMaxVersion()
+		if err != nil || v == nil {
+			return bonus, err
+		}
+		version = v.Version
+	}
+	runes, err := rd.Find(nil, map[string]interface{}{
+		"runeId":  runeIDs,
+		"version": version,
+		"status":  0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range runes {
+		for key, val := range ParseRuneStats(r.Description) {
+			bonus[key] += val
+		}
+	}
+	return bonus, nil
+}
+
+// damageTarget 计算目标穿透后的护甲和魔抗
+func damageTarget(ctx *context.Context, params *DamageParams, offense *dto.DamageOffense) (*dto.DamageTarget, error) {
+	t := params.Target
+	if t == nil {
+		t = &DamageTargetParams{}
+	}
+	target := &dto.DamageTarget{
+		Hp:         t.Hp,
+		Armor:      t.Armor,
+		MagicBlock: t.MagicBlock,
+	}
+	if t.HeroID != "" {
+		level := t.Level
+		if level == 0 {
+			level = params.Level
+		}
+		attr, err := GetAttribute(ctx, params.Platform, t.HeroID, params.Version)
+		if err != nil {
+			return nil, err
+		}
+		if attr == nil {
+			return nil, errors2.New("can not find target hero " + t.HeroID)
+		}
+		stats := heroStatsAtLevel(attr, level)
+		target.Hp, target.Armor, target.MagicBlock = stats.Hp, stats.Armor, stats.Spellblock
+	}
+
+	target.EffectiveArmor = round2(common.EffectiveResist(target.Armor, t.ArmorReduction, t.ArmorReductionRate, offense.ArmorPeneRate, offense.ArmorPene))
+	target.EffectiveMagicBlock = round2(common.EffectiveResist(target.MagicBlock, t.MagicReduction, t.MagicReductionRate, offense.MagicPeneRate, offense.MagicPene))
+	target.PhysicalMultiplier = round3(common.DamageMultiplier(target.EffectiveArmor))
+	target.MagicMultiplier = round3(common.DamageMultiplier(target.EffectiveMagicBlock))
+	return target, nil
+}
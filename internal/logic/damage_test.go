@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyAdaptive(t *testing.T) {
+	cases := []struct {
+		name  string
+		bonus map[string]float64
+		want  map[string]float64
+	}{
+		{"to ad", map[string]float64{StatAdaptive: 10, StatAD: 20}, map[string]float64{StatAD: 26}},
+		{"to ap", map[string]float64{StatAdaptive: 10, StatAP: 80, StatAD: 20}, map[string]float64{StatAP: 90, StatAD: 20}},
+		{"none", map[string]float64{StatAD: 20}, map[string]float64{StatAD: 20}},
+	}
+	for _, c := range cases {
+		applyAdaptive(c.bonus)
+		if !reflect.DeepEqual(c.bonus, c.want) {
+			t.Errorf("%s: applyAdaptive = %v, want %v", c.name, c.bonus, c.want)
+		}
+	}
+}
+
+func TestParseRuneStats(t *testing.T) {
+	cases := []struct {
+		desc string
+		want map[string]float64
+	}{
+		{"+9 适应之力", map[string]float64{StatAdaptive: 9}},
+		{"<br>+10% 攻击速度", map[string]float64{StatAttackSpeed: 10}},
+		{"+6 护甲<br>+8 魔法抗性", map[string]float64{StatArmor: 6, StatMagicBlock: 8}},
+		{"+10-180 生命值（基于等级）", map[string]float64{}},
+		{"无属性描述", map[string]float64{}},
+	}
+	for _, c := range cases {
+		if got := ParseRuneStats(c.desc); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ParseRuneStats(%q) = %v, want %v", c.desc, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package logic
+
+import (
+	errors2 "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// 出装校验规则
+const (
+	BuildRuleDuplicateUnique = "duplicateUnique"
+	BuildRuleMultipleBoots   = "multipleBoots"
+	BuildRuleMythicLimit     = "mythicLimit"
+	BuildRuleLegendaryLimit  = "legendaryLimit"
+	BuildRuleMapRestriction  = "mapRestriction"
+)
+
+// MaxBuildItems 一套出装最多6件装备
+const MaxBuildItems = 6
+
+func hasTag(item *roadmapItem, keys ...string) bool {
+	for _, tag := range item.Tags {
+		for _, key := range keys {
+			if strings.EqualFold(tag, key) || strings.Contains(tag, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isBoots(item *roadmapItem) bool {
+	return hasTag(item, "Boots", "鞋") || strings.Contains(item.Name, "之靴") || strings.Contains(item.Name, "之鞋")
+}
+
+func isMythic(item *roadmapItem) bool {
+	return hasTag(item, "Mythic", "神话") || strings.Contains(item.Desc, "神话被动")
+}
+
+// isLegendary 只按标签判断，不能再合成的成装不一定受传说装备的限制
+func isLegendary(item *roadmapItem) bool {
+	return hasTag(item, "Legendary", "传说")
+}
+
+// EquipBuild 汇总一套出装的属性、价格，并校验是否符合出装规则
+func EquipBuild(ctx *context.Context, platform int, version string, mapName string, ids []string) (*dto.RespEquipBuild, error) {
+	if len(ids) == 0 || len(ids) > MaxBuildItems {
+		return nil, fmt.Errorf("equip count must between 1 and %d", MaxBuildItems)
+	}
+	var err error
+	if version == "" {
+		version, err = currentEquipVersion(platform)
+		if err != nil {
+			return nil, err
+		}
+	}
+	items, err := loadRoadmapItems(platform, version, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RespEquipBuild{
+		Items:      make([]dto.Roadmap, 0, len(ids)),
+		Stats:      make(map[string]float64),
+		Violations: make([]*dto.BuildViolation, 0),
+	}
+
+	build := make([]*roadmapItem, 0, len(ids))
+	for _, id := range ids {
+		item, ok := items[id]
+		if !ok {
+			return nil, errors2.New("can not find equip " + id)
+		}
+		build = append(build, item)
+		resp.Items = append(resp.Items, item.Roadmap)
+		resp.TotalPrice += item.Price
+		resp.SellPrice += item.Sell
+		for key, v := range item.Stats {
+			resp.Stats[key] += v
+		}
+	}
+
+	resp.Violations = append(resp.Violations, checkBuildUnique(build)...)
+	resp.Violations = append(resp.Violations, checkBuildLimit(build)...)
+	resp.Violations = append(resp.Violations, checkBuildMaps(build, mapName)...)
+	resp.Valid = len(resp.Violations) == 0
+
+	log.Logger.Info(ctx, fmt.Sprintf("equip build platform:%d version:%s ids:%v violations:%d", platform, version, ids, len(resp.Violations)))
+	return resp, nil
+}
+
+// checkBuildUnique 唯一被动不能叠加
+func checkBuildUnique(build []*roadmapItem) []*dto.BuildViolation {
+	result := make([]*dto.BuildViolation, 0)
+	owners := make(map[string][]int)
+	order := make([]string, 0)
+	for _, item := range build {
+		for _, name := range item.Passives {
+			if len(owners[name]) == 0 {
+				order = append(order, name)
+			}
+			owners[name] = append(owners[name], item.ID)
+		}
+	}
+	for _, name := range order {
+		if len(owners[name]) < 2 {
+			continue
+		}
+		result = append(result, &dto.BuildViolation{
+			Rule:    BuildRuleDuplicateUnique,
+			Message: fmt.Sprintf("唯一被动[%s]不能叠加", name),
+			Items:   owners[name],
+		})
+	}
+	return result
+}
+
+// checkBuildLimit 鞋子、神话装备只能有一件，同一件传说装备不能重复购买
+func checkBuildLimit(build []*roadmapItem) []*dto.BuildViolation {
+	result := make([]*dto.BuildViolation, 0)
+	boots, mythic := make([]int, 0), make([]int, 0)
+	legendary := make(map[int]int)
+	for _, item := range build {
+		if isBoots(item) {
+			boots = append(boots, item.ID)
+		}
+		if isMythic(item) {
+			mythic = append(mythic, item.ID)
+		}
+		if isLegendary(item) {
+			legendary[item.ID]++
+		}
+	}
+	if len(boots) > 1 {
+		result = append(result, &dto.BuildViolation{
+			Rule:    BuildRuleMultipleBoots,
+			Message: "只能携带一双鞋子",
+			Items:   boots,
+		})
+	}
+	if len(mythic) > 1 {
+		result = append(result, &dto.BuildViolation{
+			Rule:    BuildRuleMythicLimit,
+			Message: "只能携带一件神话装备",
+			Items:   mythic,
+		})
+	}
+	dup := make([]int, 0)
+	for id, n := range legendary {
+		if n > 1 {
+			dup = append(dup, id)
+		}
+	}
+	if len(dup) > 0 {
+		sort.Ints(dup)
+		result = append(result, &dto.BuildViolation{
+			Rule:    BuildRuleLegendaryLimit,
+			Message: "同一件传说装备只能携带一件",
+			Items:   dup,
+		})
+	}
+	return result
+}
+
+// checkBuildMaps 装备必须在指定地图可用，未指定地图时所有装备至少要有一个共同的地图
+func checkBuildMaps(build []*roadmapItem, mapName string) []*dto.BuildViolation {
+	result := make([]*dto.BuildViolation, 0)
+	mapCount := make(map[string]int)
+	checked := 0
+	for _, item := range build {
+		// LOLM 没有地图数据
+		if item.Maps == "" {
+			continue
+		}
+		checked++
+		maps := splitTags(item.Maps)
+		if mapName != "" && !inArray(mapName, maps) {
+			result = append(result, &dto.BuildViolation{
+				Rule:    BuildRuleMapRestriction,
+				Message: fmt.Sprintf("[%s]不能在[%s]中使用", item.Name, mapName),
+				Items:   []int{item.ID},
+			})
+		}
+		for _, m := range maps {
+			mapCount[m]++
+		}
+	}
+	if mapName != "" || checked == 0 {
+		return result
+	}
+	for _, n := range mapCount {
+		if n == checked {
+			return result
+		}
+	}
+	ids := make([]int, 0, len(build))
+	for _, item := range build {
+		ids = append(ids, item.ID)
+	}
+	result = append(result, &dto.BuildViolation{
+		Rule:    BuildRuleMapRestriction,
+		Message: "这些装备没有可以同时使用的地图",
+		Items:   ids,
+	})
+	return result
+}
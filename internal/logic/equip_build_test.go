@@ -0,0 +1,92 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/dto"
+)
+
+func buildItem(id int, name string, tags []string, passives []string, maps string) *roadmapItem {
+	return &roadmapItem{
+		Roadmap:  dto.Roadmap{ID: id, Name: name, Maps: maps},
+		Tags:     tags,
+		Passives: passives,
+	}
+}
+
+func violationRules(vs []*dto.BuildViolation) []string {
+	rules := make([]string, 0, len(vs))
+	for _, v := range vs {
+		rules = append(rules, v.Rule)
+	}
+	return rules
+}
+
+func TestCheckBuildUnique(t *testing.T) {
+	cases := []struct {
+		name  string
+		build []*roadmapItem
+		items [][]int
+	}{
+		{"no passive", []*roadmapItem{buildItem(1, "a", nil, nil, ""), buildItem(2, "b", nil, nil, "")}, [][]int{}},
+		{"different passives", []*roadmapItem{buildItem(1, "a", nil, []string{"x"}, ""), buildItem(2, "b", nil, []string{"y"}, "")}, [][]int{}},
+		{"same passive", []*roadmapItem{buildItem(1, "a", nil, []string{"x"}, ""), buildItem(2, "b", nil, []string{"x", "y"}, "")}, [][]int{{1, 2}}},
+	}
+	for _, c := range cases {
+		got := make([][]int, 0)
+		for _, v := range checkBuildUnique(c.build) {
+			got = append(got, v.Items)
+		}
+		if !reflect.DeepEqual(got, c.items) {
+			t.Errorf("%s: checkBuildUnique = %v, want %v", c.name, got, c.items)
+		}
+	}
+}
+
+func TestCheckBuildLimit(t *testing.T) {
+	boots := buildItem(3006, "狂战士胫甲", []string{"Boots"}, nil, "")
+	swift := buildItem(3009, "轻灵之靴", []string{"Boots"}, nil, "")
+	mythicA := buildItem(6672, "海妖杀手", []string{"Mythic"}, nil, "")
+	mythicB := buildItem(6673, "不朽盾弓", []string{"Mythic"}, nil, "")
+	legend := buildItem(3031, "无尽之刃", []string{"Legendary"}, nil, "")
+	// 没有传说标签的成装可以重复
+	finished := &roadmapItem{Roadmap: dto.Roadmap{ID: 3071}, From: []string{"3133"}}
+	cases := []struct {
+		name  string
+		build []*roadmapItem
+		rules []string
+	}{
+		{"normal build", []*roadmapItem{boots, mythicA, legend, finished, finished}, []string{}},
+		{"two boots", []*roadmapItem{boots, swift}, []string{BuildRuleMultipleBoots}},
+		{"two mythic", []*roadmapItem{mythicA, mythicB}, []string{BuildRuleMythicLimit}},
+		{"duplicate legendary", []*roadmapItem{legend, legend}, []string{BuildRuleLegendaryLimit}},
+	}
+	for _, c := range cases {
+		if got := violationRules(checkBuildLimit(c.build)); !reflect.DeepEqual(got, c.rules) {
+			t.Errorf("%s: checkBuildLimit = %v, want %v", c.name, got, c.rules)
+		}
+	}
+}
+
+func TestCheckBuildMaps(t *testing.T) {
+	rift := buildItem(1, "a", nil, nil, "召唤师峡谷,嚎哭深渊")
+	aram := buildItem(2, "b", nil, nil, "嚎哭深渊")
+	arena := buildItem(3, "c", nil, nil, "斗魂竞技场")
+	lolm := buildItem(4, "d", nil, nil, "")
+	cases := []struct {
+		name    string
+		build   []*roadmapItem
+		mapName string
+		rules   []string
+	}{
+		{"shared map", []*roadmapItem{rift, aram}, "", []string{}},
+		{"no shared map", []*roadmapItem{rift, arena}, "", []string{BuildRuleMapRestriction}},
+		{"given map", []*roadmapItem{rift, aram}, "召唤师峡谷", []string{BuildRuleMapRestriction}},
+		{"lolm has no maps", []*roadmapItem{lolm, lolm}, "召唤师峡谷", []string{}},
+	}
+	for _, c := range cases {
+		if got := violationRules(checkBuildMaps(c.build, c.mapName)); !reflect.DeepEqual(got, c.rules) {
+			t.Errorf("%s: checkBuildMaps = %v, want %v", c.name, got, c.rules)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package logic
+
+import (
+	"github.com/spf13/cast"
+	"regexp"
+	"strings"
+	"whisper/internal/model"
+)
+
+// 装备属性统一的key，百分比类的属性记录的是百分数，例如 25 表示 25%
+const (
+	StatAD            = "ad"
+	StatAP            = "ap"
+	StatHP            = "hp"
+	StatMP            = "mp"
+	StatArmor         = "armor"
+	StatMagicBlock    = "magicBlock"
+	StatAttackSpeed   = "attackSpeed"
+	StatCritRate      = "critRate"
+	StatCritDamage    = "critDamage"
+	StatArmorPene     = "armorPene"
+	StatArmorPeneRate = "armorPeneRate"
+	StatMagicPene     = "magicPene"
+	StatMagicPeneRate = "magicPeneRate"
+	StatAbilityHaste  = "abilityHaste"
+	StatMoveSpeed     = "moveSpeed"
+	StatMoveRate      = "moveRate"
+	StatHPRegen       = "hpRegen"
+	StatHPRegenRate   = "hpRegenRate"
+	StatMPRegen       = "mpRegen"
+	StatMPRegenRate   = "mpRegenRate"
+	StatLifeSteal     = "lifeSteal"
+	StatSpellVamp     = "spellVamp"
+	StatOmnivamp      = "omnivamp"
+	StatHealShield    = "healShield"
+	StatTenacity      = "tenacity"
+)
+
+// EquipStatKeys 所有可用于筛选的属性
+var EquipStatKeys = []string{
+	StatAD, StatAP, StatHP, StatMP, StatArmor, StatMagicBlock, StatAttackSpeed, StatCritRate, StatCritDamage,
+	StatArmorPene, StatArmorPeneRate, StatMagicPene, StatMagicPeneRate, StatAbilityHaste, StatMoveSpeed,
+	StatMoveRate, StatHPRegen, StatHPRegenRate, StatMPRegen, StatMPRegenRate, StatLifeSteal, StatSpellVamp,
+	StatOmnivamp, StatHealShield, StatTenacity,
+}
+
+// EquipStatName 属性的展示名称
+var EquipStatName = map[string]string{
+	StatAD:            "攻击力",
+	StatAP:            "法术强度",
+	StatHP:            "生命值",
+	StatMP:            "法力值",
+	StatArmor:         "护甲",
+	StatMagicBlock:    "魔法抗性",
+	StatAttackSpeed:   "攻击速度",
+	StatCritRate:      "暴击几率",
+	StatCritDamage:    "暴击伤害",
+	StatArmorPene:     "穿甲",
+	StatArmorPeneRate: "护甲穿透",
+	StatMagicPene:     "法术穿透",
+	StatMagicPeneRate: "法术穿透",
+	StatAbilityHaste:  "技能急速",
+	StatMoveSpeed:     "移动速度",
+	StatMoveRate:      "移动速度",
+	StatHPRegen:       "生命回复",
+	StatHPRegenRate:   "基础生命值回复",
+	StatMPRegen:       "法力回复",
+	StatMPRegenRate:   "基础法力值回复",
+	StatLifeSteal:     "生命偷取",
+	StatSpellVamp:     "法术吸血",
+	StatOmnivamp:      "全能吸血",
+	StatHealShield:    "治疗和护盾强度",
+	StatTenacity:      "韧性",
+}
+
+// percentStats 以百分数记录的属性
+var percentStats = map[string]bool{
+	StatAttackSpeed: true, StatCritRate: true, StatCritDamage: true, StatArmorPeneRate: true, StatMagicPeneRate: true,
+	StatMoveRate: true, StatHPRegenRate: true, StatMPRegenRate: true, StatLifeSteal: true, StatSpellVamp: true,
+	StatOmnivamp: true, StatHealShield: true, StatTenacity: true,
+}
+
+// 装备等级，advanced、enchant 只有LOLM有
+const (
+	EquipTierBasic     = "basic"
+	EquipTierEpic      = "epic"
+	EquipTierAdvanced  = "advanced"
+	EquipTierLegendary = "legendary"
+	EquipTierEnchant   = "enchant"
+)
+
+// LOLMEquipTiers LOLM装备等级的展示顺序
+var LOLMEquipTiers = []string{EquipTierBasic, EquipTierAdvanced, EquipTierLegendary, EquipTierEnchant}
+
+var EquipTierName = map[string]string{
+	EquipTierBasic:     "基础装备",
+	EquipTierEpic:      "中级装备",
+	EquipTierAdvanced:  "进阶装备",
+	EquipTierLegendary: "传说装备",
+	EquipTierEnchant:   "附魔",
+}
+
+// EquipTier 根据合成关系判断装备等级：没有配件的是基础装备，还能继续合成的是中级装备，其余是成装
+func EquipTier(from, into string) string {
+	if len(splitRoadmapIDs(from)) == 0 {
+		return EquipTierBasic
+	}
+	if len(splitRoadmapIDs(into)) > 0 {
+		return EquipTierEpic
+	}
+	return EquipTierLegendary
+}
+
+// LOLMEquipTier LOLM装备的等级，优先使用上游的 level 字段，无法识别时按合成关系判断
+func LOLMEquipTier(equip *model.LOLMEquipment) string {
+	if strings.Contains(equip.Type+equip.Level+equip.Tags, "附魔") {
+		return EquipTierEnchant
+	}
+	level := strings.TrimSpace(equip.Level)
+	switch {
+	case level == "1" || strings.Contains(level, "基础"):
+		return EquipTierBasic
+	case level == "2" || strings.Contains(level, "进阶") || strings.Contains(level, "中级"):
+		return EquipTierAdvanced
+	case cast.ToInt(level) >= 3 || strings.Contains(level, "传说") || strings.Contains(level, "高级"):
+		return EquipTierLegendary
+	}
+	if tier := EquipTier(equip.From, equip.Into); tier != EquipTierEpic {
+		return tier
+	}
+	return EquipTierAdvanced
+}
+
+// lolStatNames LOL装备描述中的属性名称，带%时使用 rate 的key
+var lolStatNames = map[string][2]string{
+	"攻击力":     {StatAD, StatAD},
+	"法术强度":    {StatAP, StatAP},
+	"生命值":     {StatHP, StatHP},
+	"法力":      {StatMP, StatMP},
+	"法力值":     {StatMP, StatMP},
+	"护甲":      {StatArmor, StatArmor},
+	"魔法抗性":    {StatMagicBlock, StatMagicBlock},
+	"攻击速度":    {StatAttackSpeed, StatAttackSpeed},
+	"暴击几率":    {StatCritRate, StatCritRate},
+	"暴击率":     {StatCritRate, StatCritRate},
+	"暴击伤害":    {StatCritDamage, StatCritDamage},
+	"穿甲":      {StatArmorPene, StatArmorPene},
+	"护甲穿透":    {StatArmorPene, StatArmorPeneRate},
+	"法术穿透":    {StatMagicPene, StatMagicPeneRate},
+	"技能急速":    {StatAbilityHaste, StatAbilityHaste},
+	"移动速度":    {StatMoveSpeed, StatMoveRate},
+	"基础生命值回复": {StatHPRegenRate, StatHPRegenRate},
+	"基础法力值回复": {StatMPRegenRate, StatMPRegenRate},
+	"生命偷取":    {StatLifeSteal, StatLifeSteal},
+	"全能吸血":    {StatOmnivamp, StatOmnivamp},
+	"治疗和护盾强度": {StatHealShield, StatHealShield},
+	"韧性":      {StatTenacity, StatTenacity},
+	"韧性和减速抗性": {StatTenacity, StatTenacity},
+}
+
+var (
+	lolStatsRegex  = regexp.MustCompile(`(?s)<stats>(.*?)</stats>`)
+	lolStatRegex   = regexp.MustCompile(`<attention>\s*([\d.]+)\s*(%?)\s*</attention>\s*([^<]+)`)
+	uniqueRegex    = regexp.MustCompile(`唯一被动\s*[-—–]?\s*([^：:<\s]+)\s*[：:]`)
+	activeTagRegex = regexp.MustCompile(`<active>\s*([^<]+?)\s*</active>`)
+	activeRegex    = regexp.MustCompile(`(?:唯一)?主动\s*[-—–]?\s*([^：:<\s]+)\s*[：:]`)
+	htmlTagRegex   = regexp.MustCompile(`<[^>]+>`)
+	runeStatRegex  = regexp.MustCompile(`\+\s*([\d.]+)\s*(%?)\s*点?\s*(\p{Han}+)`)
+)
+
+// StatAdaptive 适应之力，1点适应之力等于0.6攻击力或1法术强度
+const StatAdaptive = "adaptive"
+
+// parseStatValue 兼容 "10"、"10%"、" 10.5 " 的写法
+func parseStatValue(s string) float64 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	return cast.ToFloat64(s)
+}
+
+// ParseLOLEquipStats 从LOL装备描述的<stats>中解析属性
+func ParseLOLEquipStats(desc string) map[string]float64 {
+	stats := make(map[string]float64)
+	block := desc
+	if m := lolStatsRegex.FindStringSubmatch(desc); len(m) > 1 {
+		block = m[1]
+	}
+	for _, m := range lolStatRegex.FindAllStringSubmatch(block, -1) {
+		name := strings.TrimSpace(htmlTagRegex.ReplaceAllString(m[3], ""))
+		keys, ok := lolStatNames[name]
+		if !ok {
+			continue
+		}
+		key := keys[0]
+		if m[2] == "%" {
+			key = keys[1]
+		}
+		stats[key] += parseStatValue(m[1])
+	}
+	return stats
+}
+
+// LOLMEquipStats LOLM的属性是单独的字段
+func LOLMEquipStats(equip *model.LOLMEquipment) map[string]float64 {
+	fields := map[string]string{
+		StatAD:            equip.Ad,
+		StatAP:            equip.MagicAttack,
+		StatHP:            equip.Hp,
+		StatMP:            equip.Mp,
+		StatArmor:         equip.Armor,
+		StatMagicBlock:    equip.MagicBlock,
+		StatAttackSpeed:   equip.AttackSpeed,
+		StatCritRate:      equip.CritRate,
+		StatCritDamage:    equip.CritDamage,
+		StatArmorPene:     equip.ArmorPene,
+		StatArmorPeneRate: equip.ArmorPeneRate,
+		StatMagicPene:     equip.MagicPene,
+		StatMagicPeneRate: equip.MagicPeneRate,
+		StatAbilityHaste:  equip.Cd,
+		StatMoveSpeed:     equip.MoveSpeed,
+		StatMoveRate:      equip.MoveRate,
+		StatHPRegen:       equip.HpRegen,
+		StatHPRegenRate:   equip.HpRegenRate,
+		StatMPRegen:       equip.MpRegen,
+		StatLifeSteal:     equip.HealthPerAttack,
+		StatSpellVamp:     equip.HealthPerMagic,
+		StatTenacity:      equip.DuctRate,
+	}
+	stats := make(map[string]float64)
+	for key, v := range fields {
+		if val := parseStatValue(v); val != 0 {
+			stats[key] = val
+		}
+	}
+	return stats
+}
+
+// ParseUniquePassives 解析装备描述中的唯一被动名称，普通被动可以叠加，不在其中
+func ParseUniquePassives(desc string) []string {
+	result := make([]string, 0)
+	exists := make(map[string]bool)
+	text := htmlTagRegex.ReplaceAllString(desc, " ")
+	for _, m := range uniqueRegex.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimSpace(m[1])
+		if name == "" || exists[name] {
+			continue
+		}
+		exists[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// ParseActiveEffects 解析装备描述中的主动效果名称
+func ParseActiveEffects(desc string) []string {
+	result := make([]string, 0)
+	exists := make(map[string]bool)
+	for _, re := range []*regexp.Regexp{activeTagRegex, activeRegex} {
+		for _, m := range re.FindAllStringSubmatch(desc, -1) {
+			name := strings.TrimSpace(m[1])
+			// 标签中是 "唯一主动 - 名称：" 的写法时由 activeRegex 解析名称
+			if name == "" || exists[name] || (re == activeTagRegex && strings.Contains(name, "主动")) {
+				continue
+			}
+			exists[name] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// ParseRuneStats 解析符文描述中的属性加成，例如 "+9 适应之力"、"+10% 攻击速度"
+func ParseRuneStats(desc string) map[string]float64 {
+	stats := make(map[string]float64)
+	text := htmlTagRegex.ReplaceAllString(desc, " ")
+	for _, m := range runeStatRegex.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimSpace(m[3])
+		if name == "适应之力" {
+			stats[StatAdaptive] += parseStatValue(m[1])
+			continue
+		}
+		keys, ok := lolStatNames[name]
+		if !ok {
+			continue
+		}
+		key := keys[0]
+		if m[2] == "%" {
+			key = keys[1]
+		}
+		stats[key] += parseStatValue(m[1])
+	}
+	return stats
+}
@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/model"
+)
+
+func TestParseStatValue(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"10", 10},
+		{"10%", 10},
+		{" 10.5 ", 10.5},
+		{"", 0},
+		{"abc", 0},
+	}
+	for _, c := range cases {
+		if got := parseStatValue(c.in); got != c.want {
+			t.Errorf("parseStatValue(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseLOLEquipStats(t *testing.T) {
+	cases := []struct {
+		name string
+		desc string
+		want map[string]float64
+	}{
+		{
+			name: "flat and percent",
+			desc: "<mainText><stats><attention>40</attention> 攻击力<br><attention>25%</attention> 攻击速度<br><attention>18%</attention> 护甲穿透</stats><br>其他</mainText>",
+			want: map[string]float64{StatAD: 40, StatAttackSpeed: 25, StatArmorPeneRate: 18},
+		},
+		{
+			name: "lethality uses flat key",
+			desc: "<stats><attention>10</attention> 护甲穿透</stats>",
+			want: map[string]float64{StatArmorPene: 10},
+		},
+		{
+			name: "unknown stat ignored",
+			desc: "<stats><attention>5</attention> 未知属性</stats>",
+			want: map[string]float64{},
+		},
+		{
+			name: "no stats block",
+			desc: "<attention>300</attention> 生命值",
+			want: map[string]float64{StatHP: 300},
+		},
+	}
+	for _, c := range cases {
+		if got := ParseLOLEquipStats(c.desc); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: ParseLOLEquipStats = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestLOLMEquipStats(t *testing.T) {
+	got := LOLMEquipStats(&model.LOLMEquipment{Ad: "40", AttackSpeed: "25%", Cd: "10", Hp: "0"})
+	want := map[string]float64{StatAD: 40, StatAttackSpeed: 25, StatAbilityHaste: 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LOLMEquipStats = %v, want %v", got, want)
+	}
+}
+
+func TestParseUniquePassives(t *testing.T) {
+	cases := []struct {
+		name string
+		desc string
+		want []string
+	}{
+		{"unique", "唯一被动 - 盛怒：攻击时获得攻击速度", []string{"盛怒"}},
+		{"unique in tag", "<passive>唯一被动 - 冰川：</passive>攻击时减速", []string{"冰川"}},
+		{"plain passive can stack", "<passive>切割</passive>：攻击时减少护甲", []string{}},
+		{"dedup", "唯一被动 - 法力流：回复法力 唯一被动 - 法力流：", []string{"法力流"}},
+	}
+	for _, c := range cases {
+		if got := ParseUniquePassives(c.desc); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: ParseUniquePassives = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestEquipTier(t *testing.T) {
+	cases := []struct {
+		from, into string
+		want       string
+	}{
+		{"", "3133", EquipTierBasic},
+		{"0", "", EquipTierBasic},
+		{"1036,1036", "3071", EquipTierEpic},
+		{"3133,1036", "", EquipTierLegendary},
+	}
+	for _, c := range cases {
+		if got := EquipTier(c.from, c.into); got != c.want {
+			t.Errorf("EquipTier(%q, %q) = %s, want %s", c.from, c.into, got, c.want)
+		}
+	}
+}
+
+func TestLOLMEquipTier(t *testing.T) {
+	cases := []struct {
+		name  string
+		equip *model.LOLMEquipment
+		want  string
+	}{
+		{"enchant type", &model.LOLMEquipment{Type: "附魔", Level: "3"}, EquipTierEnchant},
+		{"enchant tag", &model.LOLMEquipment{Tags: "鞋子,附魔"}, EquipTierEnchant},
+		{"level 1", &model.LOLMEquipment{Level: " 1 "}, EquipTierBasic},
+		{"level name basic", &model.LOLMEquipment{Level: "基础装备"}, EquipTierBasic},
+		{"level 2", &model.LOLMEquipment{Level: "2"}, EquipTierAdvanced},
+		{"level name middle", &model.LOLMEquipment{Level: "中级装备"}, EquipTierAdvanced},
+		{"level 3", &model.LOLMEquipment{Level: "3"}, EquipTierLegendary},
+		{"level name legendary", &model.LOLMEquipment{Level: "传说装备"}, EquipTierLegendary},
+		// level 无法识别时按合成关系判断，可以继续合成的是进阶装备
+		{"no from", &model.LOLMEquipment{From: "", Into: "6031"}, EquipTierBasic},
+		{"from and into", &model.LOLMEquipment{From: "1001", Into: "6031"}, EquipTierAdvanced},
+		{"from only", &model.LOLMEquipment{From: "1001,1036"}, EquipTierLegendary},
+	}
+	for _, c := range cases {
+		if got := LOLMEquipTier(c.equip); got != c.want {
+			t.Errorf("%s: LOLMEquipTier = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseActiveEffects(t *testing.T) {
+	cases := []struct {
+		name string
+		desc string
+		want []string
+	}{
+		{"tag", "<active>雷霆一击</active>：造成伤害", []string{"雷霆一击"}},
+		{"unique active", "唯一主动 - 暴雨：向前冲刺", []string{"暴雨"}},
+		{"unique active in tag", "<active>唯一主动 - 暴雨：</active>向前冲刺", []string{"暴雨"}},
+		{"dedup", "主动 - 护盾：获得护盾 <active>护盾</active>", []string{"护盾"}},
+		{"no name", "主动：回复生命值", []string{}},
+		{"empty", "", []string{}},
+	}
+	for _, c := range cases {
+		if got := ParseActiveEffects(c.desc); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: ParseActiveEffects = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -108,6 +108,7 @@ func reloadEquipmentForLOL(ctx *context.Context, equip *dto.LOLEquipment) {
 			Sell:        item.Sell,
 			Total:       item.Total,
 			Tag:         item.Tag,
+			Types:       strings.Join(item.Types, ","),
 			Keywords:    item.Keywords + "," + searchKey,
 			Version:     equip.Version,
 			FileTime:    equip.FileTime,
@@ -273,9 +274,10 @@ func extractEquipKeywords(ctx *context.Context, platform int) map[string]model.E
 			return nil
 		}
 
+		// LOL 的装备按地图分多行存储，每个地图记录一条，便于按地图筛选
 		for _, equip := range equips {
 			words := utils.ExtractKeywords(equip.Description, re)
-			result[equip.ItemId] = model.EquipIntro{
+			result[equip.ItemId+"_"+equip.Maps] = model.EquipIntro{
 				ID:        equip.ItemId,
 				Name:      equip.Name,
 				Icon:      equip.IconPath,
@@ -286,6 +288,8 @@ func extractEquipKeywords(ctx *context.Context, platform int) map[string]model.E
 				Platform:  common.PlatformForLOL,
 				Version:   equip.Version,
 				Keywords:  words,
+				Tier:      EquipTier(equip.From, equip.Into),
+				Stats:     ParseLOLEquipStats(equip.Description),
 			}
 		}
 	} else {
@@ -314,6 +318,10 @@ func extractEquipKeywords(ctx *context.Context, platform int) map[string]model.E
 				Platform:  common.PlatformForLOLM,
 				Version:   equip.Version,
 				Keywords:  words,
+				Tier:      LOLMEquipTier(equip),
+				Stats:     LOLMEquipStats(equip),
+
+				ComposeLevel: cast.ToInt(equip.ComposeLevel),
 			}
 		}
 	}
@@ -387,13 +395,38 @@ func recordMongo(ctx *context.Context, data map[string]model.EquipIntro, platfor
 	}
 }
 
-func FilterKeyWords(ctx *context.Context, keywords []string, platform int) ([]*model.EquipIntro, error) {
-	log.Logger.Info(ctx, keywords)
+// EquipFilterCond 装备筛选条件
+type EquipFilterCond struct {
+	Keywords     []string
+	Platform     int
+	Map          string
+	MinPrice     float64
+	MaxPrice     float64
+	Stats        map[string]float64 // 属性阈值，>=
+	Tier         string
+	ComposeLevel int    // 合成等级，仅LOLM，0为不区分
+	Sort         string // price或属性key
+	Order        string // asc/desc
+}
+
+// FilterKeyWords 按关键词、价格、属性、地图、等级筛选装备，同时返回每个关键词的分面数量
+func FilterKeyWords(ctx *context.Context, cond *EquipFilterCond) ([]*model.EquipIntro, map[string]int, error) {
+	log.Logger.Info(ctx, cond)
 	// FromMongo
 	md := dao.NewMongoEquipmentDAO()
 
-	kw := make([]bson.M, 0, len(keywords))
-	for _, words := range keywords {
+	mapName := cond.Map
+	if mapName == "" {
+		mapName = "召唤师峡谷"
+	}
+	// 构建查询条件
+	filter := bson.M{
+		"platform": cond.Platform,
+		"maps":     mapName,
+	}
+
+	kw := make([]bson.M, 0, len(cond.Keywords))
+	for _, words := range cond.Keywords {
 		in := strings.Split(words, ",")
 		kw = append(kw, bson.M{
 			"keywords": bson.M{
@@ -401,15 +434,80 @@ func FilterKeyWords(ctx *context.Context, keywords []string, platform int) ([]*m
 			},
 		})
 	}
-	// 构建查询条件
-	filter := bson.M{
-		"platform": platform,
-		"maps":     "召唤师峡谷",
-		"$and":     kw,
+	if len(kw) > 0 {
+		filter["$and"] = kw
+	}
+
+	price := bson.M{}
+	if cond.MinPrice > 0 {
+		price["$gte"] = cond.MinPrice
+	}
+	if cond.MaxPrice > 0 {
+		price["$lte"] = cond.MaxPrice
+	}
+	if len(price) > 0 {
+		filter["price"] = price
 	}
 
-	result, err := md.Find(ctx, filter)
-	return result, err
+	for key, v := range cond.Stats {
+		if !inArray(key, EquipStatKeys) {
+			return nil, nil, errors2.New("unknown stat " + key)
+		}
+		filter["stats."+key] = bson.M{"$gte": v}
+	}
+
+	if cond.Tier != "" {
+		filter["tier"] = cond.Tier
+	}
+	if cond.ComposeLevel > 0 {
+		filter["composelevel"] = cond.ComposeLevel
+	}
+
+	sortField := "price"
+	if cond.Sort != "" && cond.Sort != "price" {
+		if !inArray(cond.Sort, EquipStatKeys) {
+			return nil, nil, errors2.New("unknown sort " + cond.Sort)
+		}
+		sortField = "stats." + cond.Sort
+	}
+	direction := -1
+	if cond.Order == "asc" {
+		direction = 1
+	}
+
+	result, err := md.FindWithSort(ctx, filter, bson.D{{Key: sortField, Value: direction}})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 分面统计：在当前结果上再加一个关键词后还剩多少件装备
+	facets := make(map[string]int)
+	types, _ := GetEquipTypes(ctx)
+	for _, t := range types {
+		for _, sub := range t.SubCate {
+			count := 0
+			for _, equip := range result {
+				for _, word := range sub.KeywordsSlice {
+					if inArray(word, equip.Keywords) {
+						count++
+						break
+					}
+				}
+			}
+			facets[sub.KeywordsStr] = count
+		}
+	}
+	// 等级、合成等级的分面，key 为 tier:xxx、composeLevel:n
+	for _, equip := range result {
+		if equip.Tier != "" {
+			facets["tier:"+equip.Tier]++
+		}
+		if equip.ComposeLevel > 0 {
+			facets[fmt.Sprintf("composeLevel:%d", equip.ComposeLevel)]++
+		}
+	}
+
+	return result, facets, nil
 }
 
 func convRoadmapData4LOL(data any) string {
@@ -435,125 +533,40 @@ func convRoadmapData4LOLM(data any) (string, []string) {
 }
 
 func GetRoadmap(ctx *context.Context, version string, platform int, equipID string, maps []string) (*dto.RespRoadmap, error) {
-	resp := dto.RespRoadmap{}
-	if platform == common.PlatformForLOL {
-		ed := dao.NewLOLEquipmentDAO()
-		roadmap, err := ed.GetRoadmap(version, equipID, maps)
-		if err != nil {
-			return nil, err
-		}
-		if len(roadmap["current"]) == 0 {
-			return nil, errors.New("current equip can not find data")
-		}
-		resp.Current = dto.Roadmap{
-			ID:        cast.ToInt(roadmap["current"][0].ItemId),
-			Name:      roadmap["current"][0].Name,
-			Icon:      roadmap["current"][0].IconPath,
-			Maps:      roadmap["current"][0].Maps,
-			Level:     "",
-			Plaintext: roadmap["current"][0].Plaintext,
-			Desc:      roadmap["current"][0].Description,
-			Price:     cast.ToInt(roadmap["current"][0].Total),
-			Sell:      cast.ToInt(roadmap["current"][0].Sell),
-			Version:   roadmap["current"][0].Version,
-			Platform:  common.PlatformForLOL,
-		}
-
-		for _, equip := range roadmap["into"] {
-			resp.Into = append(resp.Into, dto.Roadmap{
-				ID:        cast.ToInt(equip.ItemId),
-				Name:      equip.Name,
-				Icon:      equip.IconPath,
-				Maps:      equip.Maps,
-				Level:     "",
-				Plaintext: equip.Plaintext,
-				Desc:      equip.Description,
-				Price:     cast.ToInt(equip.Total),
-				Sell:      cast.ToInt(equip.Sell),
-				Version:   equip.Version,
-			})
-		}
-
-		fromPrice := 0
-		for _, equip := range roadmap["from"] {
-			price := cast.ToInt(equip.Total)
-			resp.From = append(resp.From, dto.Roadmap{
-				ID:        cast.ToInt(equip.ItemId),
-				Name:      equip.Name,
-				Icon:      equip.IconPath,
-				Maps:      equip.Maps,
-				Level:     "",
-				Plaintext: equip.Plaintext,
-				Desc:      equip.Description,
-				Price:     price,
-				Sell:      cast.ToInt(equip.Sell),
-				Version:   equip.Version,
-			})
-
-			fromPrice += price
-		}
+	items, err := loadRoadmapItems(platform, version, maps)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := items[equipID]
+	if !ok {
+		return nil, errors2.New("current equip can not find data")
+	}
 
-		resp.GapPriceFrom = resp.Current.Price - fromPrice
-	} else {
-		ed := dao.NewLOLMEquipmentDAO()
-		roadmap, err := ed.GetRoadmap(version, equipID, maps)
-		if err != nil {
-			return nil, err
-		}
-		if len(roadmap["current"]) == 0 {
-			return nil, errors.New("current equip can not find data")
-		}
-		resp.Current = dto.Roadmap{
-			ID:        cast.ToInt(roadmap["current"][0].EquipId),
-			Name:      roadmap["current"][0].Name,
-			Icon:      roadmap["current"][0].IconPath,
-			Maps:      "",
-			Level:     roadmap["current"][0].Level,
-			Plaintext: "",
-			Desc:      roadmap["current"][0].Description,
-			Price:     cast.ToInt(&roadmap["current"][0].Price),
-			Sell:      0,
-			Version:   roadmap["current"][0].Version,
-			Platform:  common.PlatformForLOLM,
-		}
+	resp := dto.RespRoadmap{
+		Current: current.Roadmap,
+	}
 
-		for _, equip := range roadmap["into"] {
-			resp.Into = append(resp.Into, dto.Roadmap{
-				ID:        cast.ToInt(equip.EquipId),
-				Name:      equip.Name,
-				Icon:      equip.IconPath,
-				Maps:      "",
-				Level:     "",
-				Plaintext: "",
-				Desc:      equip.Description,
-				Price:     cast.ToInt(equip.Price),
-				Sell:      0,
-				Version:   equip.Version,
-			})
+	// from 保留重复的配件，例如两把长剑
+	fromPrice := 0
+	for _, fid := range current.From {
+		if equip, ok := items[fid]; ok {
+			resp.From = append(resp.From, equip.Roadmap)
+			fromPrice += equip.Price
 		}
-
-		fromPrice := 0
-		for _, equip := range roadmap["from"] {
-			price := cast.ToInt(equip.Price)
-			resp.From = append(resp.From, dto.Roadmap{
-				ID:        cast.ToInt(equip.EquipId),
-				Name:      equip.Name,
-				Icon:      equip.IconPath,
-				Maps:      "",
-				Level:     "",
-				Plaintext: "",
-				Desc:      equip.Description,
-				Price:     price,
-				Sell:      0,
-				Version:   equip.Version,
-			})
-			fromPrice += price
+	}
+	for _, iid := range current.Into {
+		if equip, ok := items[iid]; ok {
+			resp.Into = append(resp.Into, equip.Roadmap)
 		}
-
-		resp.GapPriceFrom = resp.Current.Price - fromPrice
 	}
+	resp.GapPriceFrom = resp.Current.Price - fromPrice
+
+	// 完整的合成树
+	resp.Tree = buildRoadmapTree(items, equipID, 1, map[string]bool{})
+	resp.TotalCost = roadmapTreeCost(resp.Tree)
+	resp.IntoTree = buildIntoTree(items, equipID, map[string]bool{})
 
-	// ===================
+	log.Logger.Info(ctx, fmt.Sprintf("roadmap equip:%s platform:%d version:%s total cost:%d", equipID, platform, version, resp.TotalCost))
 
 	return &resp, nil
 }
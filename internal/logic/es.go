@@ -30,8 +30,14 @@ type SearchParams struct {
 	Version  string   `json:"version,omitempty"`
 	Way      []string `json:"way,omitempty"`
 	Map      []string `json:"map,omitempty"`
+
+	Tier         string `json:"tier,omitempty"`         // 装备等级，仅装备索引
+	ComposeLevel string `json:"composeLevel,omitempty"` // 合成等级，仅装备索引
 }
 
+// equipFacetFields 装备搜索返回分面数量的字段，key 与 /equip/filter 的分面一致
+var equipFacetFields = []string{"tier", "composeLevel"}
+
 func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error) {
 	indexName := p.Category
 	if indexName == "" {
@@ -62,6 +68,12 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 			maps = append(maps, m)
 		}
 		query = query.Must(elastic.NewTermsQuery("maps", maps...))
+		if p.Tier != "" {
+			query = query.Must(elastic.NewTermQuery("tier", p.Tier))
+		}
+		if p.ComposeLevel != "" {
+			query = query.Must(elastic.NewTermQuery("composeLevel", p.ComposeLevel))
+		}
 	}
 
 	// 端游or手游
@@ -72,14 +84,20 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 
 	sortByScore := elastic.NewFieldSort("_score").Desc()
 
-	res, err := es.ESClient.Search().
+	search := es.ESClient.Search().
 		Index(indexName).
 		Highlight(hl).
 		Query(query).
 		SortBy(sortByScore).
 		From(0).Size(20).
-		Pretty(true).
-		Do(ctx)
+		Pretty(true)
+	// 装备按等级、合成等级分面
+	if indexName == equipModel.GetIndexName() {
+		for _, field := range equipFacetFields {
+			search = search.Aggregation(field, elastic.NewTermsAggregation().Field(field))
+		}
+	}
+	res, err := search.Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +108,18 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 	if err != nil {
 		return nil, err
 	}
+	for _, field := range equipFacetFields {
+		agg, ok := res.Aggregations.Terms(field)
+		if !ok {
+			continue
+		}
+		if resp.Facets == nil {
+			resp.Facets = make(map[string]int)
+		}
+		for _, bucket := range agg.Buckets {
+			resp.Facets[fmt.Sprintf("%s:%v", field, bucket.Key)] = int(bucket.DocCount)
+		}
+	}
 
 	switch indexName {
 	case equipModel.GetIndexName():
@@ -117,6 +147,12 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 			//resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("Sell:%s", hitData.Sell))
 			resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("价格:%s", hitData.Total))
 			resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("Version:%s", hitData.Version))
+			if name, ok := EquipTierName[hitData.Tier]; ok {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, name)
+			}
+			if hitData.ComposeLevel != "" && hitData.ComposeLevel != "0" {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("合成等级:%s", hitData.ComposeLevel))
+			}
 			if hitData.Platform != cast.ToString(common.PlatformForLOLM) {
 				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("%s", hitData.Maps))
 			}
@@ -141,6 +177,21 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 			resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, strings.Split(hitData.Roles, ",")...)
 			resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("Version:%s", hitData.Version))
 		}
+
+		// 按强势期给英雄打标签
+		heroIDs := make([]string, 0, len(resp.Hits))
+		for _, hit := range resp.Hits {
+			heroIDs = append(heroIDs, hit.Source.ID)
+		}
+		phases, err := heroGamePhases(cast.ToInt(p.Platform), heroIDs)
+		if err != nil {
+			log.Logger.Warn(ctx, "heroGamePhases:", err)
+		}
+		for i, hit := range resp.Hits {
+			if phase, ok := phases[hit.Source.ID]; ok {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, common.GamePhaseTag[phase])
+			}
+		}
 	case new(model.ESRune).GetIndexName():
 		for i, hit := range resp.Hits {
 			sourceStr, _ := json.Marshal(hit.TmpSource)
@@ -168,6 +219,32 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 			}
 			resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("Version:%s", hitData.Version))
 		}
+	case new(model.ESSkin).GetIndexName():
+		for i, hit := range resp.Hits {
+			sourceStr, _ := json.Marshal(hit.TmpSource)
+			hitData := model.ESSkin{}
+			err = json.Unmarshal(sourceStr, &hitData)
+			if err != nil {
+				return nil, err
+			}
+			resp.Hits[i].Source.ID = hitData.ID
+			resp.Hits[i].Source.Name = hitData.Name
+			resp.Hits[i].Source.IconPath = hitData.IconPath
+			resp.Hits[i].Source.MainImg = hitData.MainImg
+			resp.Hits[i].Source.Description = hitData.Description
+			resp.Hits[i].Source.Version = hitData.Version
+			resp.Hits[i].Source.Platform = hitData.Platform
+
+			if hitData.SuitType != "" {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, hitData.SuitType)
+			}
+			if year := skinYear(hitData.PublishTime); year != "" {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("%s年", year))
+			}
+			if hitData.Chromas > 0 {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("炫彩:%d", hitData.Chromas))
+			}
+		}
 	case new(model.ESSkill).GetIndexName():
 		for i, hit := range resp.Hits {
 			sourceStr, _ := json.Marshal(hit.TmpSource)
@@ -187,6 +264,12 @@ func EsSearch(ctx *context.Context, p *SearchParams) (*dto.EsResultHits, error)
 			if hitData.CoolDown != "" {
 				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("冷却:%s", hitData.CoolDown))
 			}
+			if hitData.UnlockLv != "" {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("解锁等级:%s", hitData.UnlockLv))
+			}
+			for _, mode := range parseSkillModes(hitData.Maps) {
+				resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, SkillModeName[mode])
+			}
 			resp.Hits[i].Source.Tags = append(resp.Hits[i].Source.Tags, fmt.Sprintf("Version:%s", hitData.Version))
 		}
 	}
@@ -257,6 +340,8 @@ func mysql2es(ctx *context.Context, tblName string, wg *sync.WaitGroup) error {
 
 		skillModel   model.LOLSkill
 		m_skillModel model.LOLMSkill
+
+		skinModel model.HeroSkin
 	)
 
 	switch tblName {
@@ -300,6 +385,11 @@ func mysql2es(ctx *context.Context, tblName string, wg *sync.WaitGroup) error {
 		if err := buildMSkillIndex(ctx); err != nil {
 			return err
 		}
+	case skinModel.TableName():
+		log.Logger.Info(ctx, "开始处理:", skinModel.TableName())
+		if err := buildSkinIndex(ctx); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -563,6 +653,7 @@ func buildEquipIndex(ctx *context.Context) error {
 					//From:         tmp.From,  // todo
 					//Into:         tmp.Into,  // todo
 					//Types:        tmp.Types, // todo
+					Tier:     EquipTier(tmp.From, tmp.Into),
 					Version:  tmp.Version,
 					FileTime: tmp.FileTime,
 					Platform: strconv.Itoa(common.PlatformForLOL),
@@ -645,10 +736,12 @@ func buildMEquipIndex(ctx *context.Context) error {
 					Maps:     "召唤师峡谷",
 					//From:     tmp.From, // todo
 					//Into:     tmp.Into, // todo
-					Types:    tmp.Type,
-					Version:  tmp.Version,
-					FileTime: tmp.FileTime,
-					Platform: strconv.Itoa(common.PlatformForLOLM),
+					Types:        tmp.Type,
+					Tier:         LOLMEquipTier(tmp),
+					ComposeLevel: tmp.ComposeLevel,
+					Version:      tmp.Version,
+					FileTime:     tmp.FileTime,
+					Platform:     strconv.Itoa(common.PlatformForLOLM),
 				})
 
 				err2 := ed.Equipment2ES(ctx, esEquip)
@@ -870,6 +963,7 @@ func buildSkillIndex(ctx *context.Context) error {
 					Keywords:    tmp.Keywords,
 					Maps:        tmp.Gamemode,
 					CoolDown:    tmp.Cooldown,
+					UnlockLv:    tmp.Summonerlevel,
 					Version:     tmp.Version,
 					FileTime:    tmp.FileTime,
 					Platform:    strconv.Itoa(common.PlatformForLOL),
@@ -942,6 +1036,7 @@ func buildMSkillIndex(ctx *context.Context) error {
 					Keywords:    tmp.Keywords,
 					Maps:        tmp.Mode,
 					CoolDown:    tmp.Cd,
+					UnlockLv:    tmp.Unlocklv,
 					Version:     tmp.Version,
 					FileTime:    tmp.FileTime,
 					Platform:    strconv.Itoa(common.PlatformForLOLM),
@@ -965,6 +1060,52 @@ func buildMSkillIndex(ctx *context.Context) error {
 	return nil
 }
 
+// buildSkinIndex 皮肤索引，炫彩不单独建索引，记录在所属皮肤的 chromas 中
+func buildSkinIndex(ctx *context.Context) error {
+	rows, err := dao.NewHeroSkinDAO().Find(skinFields, nil)
+	if err != nil {
+		return err
+	}
+
+	chromas := make(map[string]int)
+	for _, row := range rows {
+		if isChroma(row) {
+			chromas[row.ChromasBelongId]++
+		}
+	}
+
+	esData := make([]*model.ESSkin, 0, len(rows))
+	for _, row := range rows {
+		if isChroma(row) {
+			continue
+		}
+		esData = append(esData, &model.ESSkin{
+			ID:          row.SkinId,
+			HeroId:      row.HeroId,
+			Name:        row.Name,
+			HeroName:    row.HeroName + " " + row.HeroTitle,
+			Keywords:    skinKeywords(row),
+			Description: row.Description,
+			SuitType:    row.SuitType,
+			EmblemsName: row.EmblemsName,
+			IconPath:    row.IconImg,
+			MainImg:     row.MainImg,
+			PublishTime: row.PublishTime,
+			Chromas:     chromas[row.SkinId],
+			Version:     row.Version,
+			FileTime:    row.FileTime,
+			Platform:    strconv.Itoa(row.Platform),
+		})
+	}
+
+	err = dao.NewESSkinDAO().Skin2ES(ctx, esData)
+	if err != nil {
+		return err
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Skin Task Done: allTask:%d", len(esData)))
+	return nil
+}
+
 // 创建索引
 func createIndex(ctx *context.Context) error {
 	// equipment
@@ -985,6 +1126,11 @@ func createIndex(ctx *context.Context) error {
 		return err
 	}
 
+	// skin
+	if err := dao.NewESSkinDAO().CreateIndex(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1007,5 +1153,10 @@ func deleteIndex(ctx *context.Context) error {
 		return err
 	}
 
+	// skin
+	if err := dao.NewESSkinDAO().DeleteIndex(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// tierLevel 查询 heroes_position 使用的分段
+// LOL的上游没有分段数据，统一用 LevelDefault；LOLM的 tier 为 LevelDefault 表示未指定分段，取钻石以上
+func tierLevel(platform, tier int) int {
+	if platform == common.PlatformForLOL {
+		return common.LevelDefault
+	}
+	if tier == common.LevelDefault {
+		return common.LevelDiamond
+	}
+	return tier
+}
+
+// getHeroPositions 批量获取英雄在某个分段下各位置的数据，key为英雄ID
+func getHeroPositions(platform, tier int, heroIDs []string) (map[string][]*model.HeroesPosition, error) {
+	rows, err := dao.NewHeroesPositionDAO().Find(map[string]interface{}{
+		"heroId":   heroIDs,
+		"platform": platform,
+		"level":    tierLevel(platform, tier),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]*model.HeroesPosition)
+	for _, row := range rows {
+		result[row.HeroId] = append(result[row.HeroId], row)
+	}
+	for _, hp := range result {
+		sort.Slice(hp, func(i, j int) bool {
+			return hp[i].ShowRate > hp[j].ShowRate
+		})
+	}
+	return result, nil
+}
+
+func convHeroPosition(row *model.HeroesPosition) *dto.HeroPosition {
+	return &dto.HeroPosition{
+		Pos:           row.Pos,
+		Level:         row.Level,
+		WinRate:       round2(float64(row.WinRate) / 100),
+		ShowRate:      round2(float64(row.ShowRate) / 100),
+		BanRate:       round2(float64(row.BanRate) / 100),
+		Strength:      row.Strength,
+		StrengthLevel: row.StrengthLevel,
+	}
+}
+
+// filterHeroesByTier 只保留在该分段有数据的英雄，保持原有顺序，tier为 LevelDefault 时不过滤
+func filterHeroesByTier(ctx *context.Context, platform, tier int, heroes []*dto.SearchResultList) []*dto.SearchResultList {
+	if platform == common.PlatformForLOL || tier == common.LevelDefault || len(heroes) == 0 {
+		return heroes
+	}
+
+	ids := make([]string, 0, len(heroes))
+	for _, hero := range heroes {
+		ids = append(ids, hero.Id)
+	}
+	positions, err := getHeroPositions(platform, tier, ids)
+	if err != nil {
+		log.Logger.Warn(ctx, "getHeroPositions:", err)
+		return heroes
+	}
+
+	result := make([]*dto.SearchResultList, 0, len(heroes))
+	for _, hero := range heroes {
+		hp, ok := positions[hero.Id]
+		if !ok {
+			continue
+		}
+		hero.Tags = append(hero.Tags, fmt.Sprintf("胜率:%.2f%%", float64(hp[0].WinRate)/100))
+		result = append(result, hero)
+	}
+	return result
+}
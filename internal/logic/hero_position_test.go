@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+)
+
+func TestTierLevel(t *testing.T) {
+	cases := []struct {
+		platform int
+		tier     int
+		want     int
+	}{
+		{common.PlatformForLOL, common.LevelDefault, common.LevelDefault},
+		{common.PlatformForLOL, common.LevelKing, common.LevelDefault},
+		// LOLM 未指定分段时保持原来的钻石以上
+		{common.PlatformForLOLM, common.LevelDefault, common.LevelDiamond},
+		{common.PlatformForLOLM, common.LevelMaster, common.LevelMaster},
+	}
+	for _, c := range cases {
+		if got := tierLevel(c.platform, c.tier); got != c.want {
+			t.Errorf("tierLevel(%d, %d) = %d, want %d", c.platform, c.tier, got, c.want)
+		}
+	}
+}
+
+func TestConvHeroPosition(t *testing.T) {
+	got := convHeroPosition(&model.HeroesPosition{
+		Pos:           "mid",
+		Level:         common.LevelKing,
+		WinRate:       5123,
+		ShowRate:      812,
+		BanRate:       5,
+		StrengthLevel: "T1",
+	})
+	if got.Pos != "mid" || got.Level != common.LevelKing || got.StrengthLevel != "T1" {
+		t.Errorf("convHeroPosition = %+v", got)
+	}
+	if got.WinRate != 51.23 || got.ShowRate != 8.12 || got.BanRate != 0.05 {
+		t.Errorf("rates = %v %v %v, want 51.23 8.12 0.05", got.WinRate, got.ShowRate, got.BanRate)
+	}
+}
+
+func TestLOLMHeroPositions(t *testing.T) {
+	rankList := &dto.HeroRankList{Data: map[int]map[int][]dto.HeroRankInfo{
+		// 分段0与未指定分段冲突，不保存
+		common.LevelDefault: {1: {{HeroId: "10001"}}},
+		common.LevelDiamond: {1: {{HeroId: "10001", Dtstatdate: "20231020"}}, 5: {{HeroId: "10002"}}},
+		common.LevelKing:    {2: {{HeroId: "10003", StrengthLevel: "T0"}}},
+	}}
+	got := make(map[string]*model.HeroesPosition)
+	for _, row := range lolmHeroPositions(rankList) {
+		got[fmt.Sprintf("%s:%s:%d", row.HeroId, row.Pos, row.Level)] = row
+		if row.Level == common.LevelDefault || row.Platform != common.PlatformForLOLM {
+			t.Errorf("unexpected row %+v", row)
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("lolmHeroPositions = %d rows, want 3", len(got))
+	}
+	if row := got["10001:mid:1"]; row == nil || row.Version != "20231020" {
+		t.Errorf("diamond mid row = %+v", row)
+	}
+	if row := got["10003:top:3"]; row == nil || row.StrengthLevel != "T0" {
+		t.Errorf("king top row = %+v", row)
+	}
+	if got["10002:jungle:1"] == nil {
+		t.Errorf("diamond jungle row missing")
+	}
+}
@@ -0,0 +1,71 @@
+package logic
+
+import (
+	errors2 "errors"
+	"github.com/spf13/cast"
+	"math"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	"whisper/pkg/context"
+)
+
+// HeroStats 计算英雄在 1~18 级的属性，level 不为0时只返回该等级
+func HeroStats(ctx *context.Context, platform int, heroID string, version string, level int) (*dto.RespHeroStats, error) {
+	if level != 0 && (level < common.MinHeroLevel || level > common.MaxHeroLevel) {
+		return nil, errors2.New("level out of range")
+	}
+	attr, err := GetAttribute(ctx, platform, heroID, version)
+	if err != nil {
+		return nil, err
+	}
+	if attr == nil {
+		return nil, errors2.New("can not find hero " + heroID)
+	}
+
+	resp := &dto.RespHeroStats{
+		HeroId:   attr.HeroId,
+		Name:     attr.Name,
+		Title:    attr.Title,
+		Version:  attr.Version,
+		Platform: attr.Platform,
+		Levels:   make([]*dto.HeroLevelStats, 0, common.MaxHeroLevel),
+	}
+
+	for l := common.MinHeroLevel; l <= common.MaxHeroLevel; l++ {
+		if level != 0 && l != level {
+			continue
+		}
+		resp.Levels = append(resp.Levels, heroStatsAtLevel(attr, l))
+	}
+	return resp, nil
+}
+
+// heroStatsAtLevel 英雄在 level 级时的白字属性
+func heroStatsAtLevel(attr *model.HeroAttribute, level int) *dto.HeroLevelStats {
+	f := cast.ToFloat64
+	asGrowth := common.StatGrowth(f(attr.Attackspeedperlevel), level)
+	return &dto.HeroLevelStats{
+		Level:            level,
+		Hp:               round2(common.StatAtLevel(f(attr.Hp), f(attr.Hpperlevel), level)),
+		Mp:               round2(common.StatAtLevel(f(attr.Mp), f(attr.Mpperlevel), level)),
+		Attackdamage:     round2(common.StatAtLevel(f(attr.Attackdamage), f(attr.Attackdamageperlevel), level)),
+		Attackspeed:      round3(common.AttackSpeedAtLevel(f(attr.Attackspeed), f(attr.Attackspeedperlevel), 0, level)),
+		BonusAttackspeed: round2(asGrowth),
+		Armor:            round2(common.StatAtLevel(f(attr.Armor), f(attr.Armorperlevel), level)),
+		Spellblock:       round2(common.StatAtLevel(f(attr.Spellblock), f(attr.Spellblockperlevel), level)),
+		Hpregen:          round2(common.StatAtLevel(f(attr.Hpregen), f(attr.Hpregenperlevel), level)),
+		Mpregen:          round2(common.StatAtLevel(f(attr.Mpregen), f(attr.Mpregenperlevel), level)),
+		Crit:             f(attr.Crit),
+		Movespeed:        f(attr.Movespeed),
+		Attackrange:      f(attr.Attackrange),
+	}
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+func round3(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/model"
+)
+
+func TestHeroStatsAtLevel(t *testing.T) {
+	attr := &model.HeroAttribute{
+		Hp:                   "600",
+		Hpperlevel:           "100",
+		Attackdamage:         "60",
+		Attackdamageperlevel: "3",
+		Attackspeed:          "0.625",
+		Attackspeedperlevel:  "3",
+		Armor:                "30",
+		Armorperlevel:        "4",
+		Crit:                 "0",
+		Movespeed:            "340",
+	}
+	cases := []struct {
+		level       int
+		hp, ad      float64
+		attackspeed float64
+		armor       float64
+	}{
+		{1, 600, 60, 0.625, 30},
+		{18, 2300, 111, 0.944, 98},
+	}
+	for _, c := range cases {
+		s := heroStatsAtLevel(attr, c.level)
+		if s.Hp != c.hp || s.Attackdamage != c.ad || s.Attackspeed != c.attackspeed || s.Armor != c.armor {
+			t.Errorf("level %d: got hp=%v ad=%v as=%v armor=%v", c.level, s.Hp, s.Attackdamage, s.Attackspeed, s.Armor)
+		}
+		if s.Movespeed != 340 {
+			t.Errorf("level %d: movespeed = %v", c.level, s.Movespeed)
+		}
+	}
+}
@@ -21,6 +21,7 @@ func QueryHeroes(ctx *context.Context, platform int) (any, error) {
 		if err != nil {
 			log.Logger.Warn(ctx, err)
 		}
+		recordLOLRotation(ctx, heroList)
 		reloadHeroesForLOL(ctx, heroList)
 		return heroList, nil
 	} else if platform == common.PlatformForLOLM {
@@ -28,6 +29,7 @@ func QueryHeroes(ctx *context.Context, platform int) (any, error) {
 		if err != nil {
 			log.Logger.Warn(ctx, err)
 		}
+		recordLOLMRotation(ctx, heroList)
 		reloadHeroesForLOLM(ctx, heroList)
 		return heroList, nil
 	}
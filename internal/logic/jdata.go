@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"encoding/json"
+	"strings"
+	"whisper/internal/logic/common"
+)
+
+// jdataRecord 上游统计数据中的一条记录
+// 上游的 skilllist、styledetails 等字段是json字符串，结构可能是数组，也可能是以位置为key的对象，
+// 这里统一展开成记录，并带上所在的位置
+type jdataRecord struct {
+	Lane string
+	Data map[string]interface{}
+}
+
+func isPositionName(name string) bool {
+	for _, pos := range common.PositionNameEN {
+		if pos == name {
+			return true
+		}
+	}
+	return false
+}
+
+// walkJData 递归展开上游json，match 判断一个对象是否是需要的记录
+func walkJData(v interface{}, lane string, match func(m map[string]interface{}) bool, result *[]jdataRecord) {
+	switch data := v.(type) {
+	case string:
+		s := strings.TrimSpace(data)
+		if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+			var nested interface{}
+			if err := json.Unmarshal([]byte(s), &nested); err == nil {
+				walkJData(nested, lane, match, result)
+			}
+		}
+	case []interface{}:
+		for _, item := range data {
+			walkJData(item, lane, match, result)
+		}
+	case map[string]interface{}:
+		if match(data) {
+			l := lane
+			if s, ok := data["lane"].(string); ok && s != "" {
+				l = s
+			}
+			*result = append(*result, jdataRecord{Lane: l, Data: data})
+			return
+		}
+		for k, item := range data {
+			l := lane
+			if isPositionName(k) {
+				l = k
+			}
+			walkJData(item, l, match, result)
+		}
+	}
+}
+
+// parseJData 解析上游的json字符串
+func parseJData(raw string, match func(m map[string]interface{}) bool) []jdataRecord {
+	result := make([]jdataRecord, 0)
+	walkJData(raw, "", match, &result)
+	return result
+}
@@ -0,0 +1,149 @@
+package logic
+
+import (
+	errors2 "errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+type LOLMEquipParams struct {
+	Version      string
+	Tier         string
+	ComposeLevel int // 0 表示不区分合成等级
+}
+
+// equipStatList 按 EquipStatKeys 的顺序输出属性
+func equipStatList(stats map[string]float64) []*dto.EquipStat {
+	result := make([]*dto.EquipStat, 0, len(stats))
+	for _, key := range EquipStatKeys {
+		value, ok := stats[key]
+		if !ok {
+			continue
+		}
+		result = append(result, &dto.EquipStat{
+			Key:     key,
+			Name:    EquipStatName[key],
+			Value:   value,
+			Percent: percentStats[key],
+		})
+	}
+	return result
+}
+
+func intIDs(ids []string) []int {
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, cast.ToInt(id))
+	}
+	return result
+}
+
+// GetLOLMEquips LOLM装备按 基础、进阶、传说、附魔 分组，附带属性、主动效果和可选的附魔
+func GetLOLMEquips(ctx *context.Context, p *LOLMEquipParams) (*dto.RespLOLMEquip, error) {
+	version := p.Version
+	if version == "" {
+		v, err := currentEquipVersion(common.PlatformForLOLM)
+		if err != nil {
+			return nil, err
+		}
+		version = v
+	}
+	equips, err := dao.NewLOLMEquipmentDAO().GetLOLMEquipment(version)
+	if err != nil {
+		return nil, err
+	}
+	if len(equips) == 0 {
+		return nil, errors2.New("equipment not found, version:" + version)
+	}
+
+	items := make([]*dto.LOLMEquipItem, 0, len(equips))
+	enchants := make([]int, 0)
+	for _, equip := range equips {
+		item := &dto.LOLMEquipItem{
+			ID:           cast.ToInt(equip.EquipId),
+			Name:         equip.Name,
+			Icon:         equip.IconPath,
+			Desc:         equip.Description,
+			Price:        cast.ToInt(equip.Price),
+			Tier:         LOLMEquipTier(equip),
+			ComposeLevel: cast.ToInt(equip.ComposeLevel),
+			Type:         equip.Type,
+			Tags:         splitTags(equip.Tags),
+			Stats:        equipStatList(LOLMEquipStats(equip)),
+			Actives:      ParseActiveEffects(equip.Description),
+			Passives:     ParseUniquePassives(equip.Description),
+			From:         intIDs(splitRoadmapIDs(equip.From)),
+			Into:         intIDs(splitRoadmapIDs(equip.Into)),
+			Enchants:     make([]int, 0),
+			Version:      equip.Version,
+		}
+		if item.Tier == EquipTierEnchant {
+			enchants = append(enchants, item.ID)
+		}
+		items = append(items, item)
+	}
+	sort.Ints(enchants)
+
+	// 附魔只能加在传说鞋子上，上游的 into 中有附魔时以 into 为准
+	tiers := make(map[int]string, len(items))
+	for _, item := range items {
+		tiers[item.ID] = item.Tier
+	}
+	for _, item := range items {
+		for _, id := range item.Into {
+			if tiers[id] == EquipTierEnchant {
+				item.Enchants = append(item.Enchants, id)
+			}
+		}
+		isBoot := strings.Contains(item.Type+item.Name, "鞋") || strings.Contains(item.Name, "靴")
+		if len(item.Enchants) == 0 && item.Tier == EquipTierLegendary && isBoot {
+			item.Enchants = append(item.Enchants, enchants...)
+		}
+	}
+
+	resp := &dto.RespLOLMEquip{
+		Version: version,
+		Tiers:   make([]*dto.LOLMEquipTier, 0, len(LOLMEquipTiers)),
+	}
+	groups := make(map[string]*dto.LOLMEquipTier)
+	for _, tier := range LOLMEquipTiers {
+		if p.Tier != "" && tier != p.Tier {
+			continue
+		}
+		groups[tier] = &dto.LOLMEquipTier{Tier: tier, Name: EquipTierName[tier], Items: make([]*dto.LOLMEquipItem, 0)}
+		resp.Tiers = append(resp.Tiers, groups[tier])
+	}
+	for _, item := range items {
+		group, ok := groups[item.Tier]
+		if !ok {
+			continue
+		}
+		if p.ComposeLevel > 0 && item.ComposeLevel != p.ComposeLevel {
+			continue
+		}
+		group.Items = append(group.Items, item)
+	}
+	total := 0
+	for _, group := range resp.Tiers {
+		sort.SliceStable(group.Items, func(i, j int) bool {
+			a, b := group.Items[i], group.Items[j]
+			if a.ComposeLevel != b.ComposeLevel {
+				return a.ComposeLevel < b.ComposeLevel
+			}
+			if a.Price != b.Price {
+				return a.Price < b.Price
+			}
+			return a.ID < b.ID
+		})
+		total += len(group.Items)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("lolm equips version:%s tier:%s composeLevel:%d total:%d", version, p.Tier, p.ComposeLevel, total))
+	return resp, nil
+}
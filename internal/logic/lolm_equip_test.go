@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/dto"
+)
+
+func TestEquipStatList(t *testing.T) {
+	got := equipStatList(map[string]float64{StatAttackSpeed: 25, StatAD: 40, "unknown": 1})
+	// 按 EquipStatKeys 的顺序，不在其中的属性不输出
+	want := []*dto.EquipStat{
+		{Key: StatAD, Name: EquipStatName[StatAD], Value: 40},
+		{Key: StatAttackSpeed, Name: EquipStatName[StatAttackSpeed], Value: 25, Percent: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("equipStatList = %+v, want %+v", got, want)
+	}
+	if got := equipStatList(nil); got == nil || len(got) != 0 {
+		t.Errorf("equipStatList(nil) = %v, want empty", got)
+	}
+}
+
+func TestIntIDs(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want []int
+	}{
+		{[]string{"6031", "1001"}, []int{6031, 1001}},
+		{[]string{"x"}, []int{0}},
+		{nil, []int{}},
+	}
+	for _, c := range cases {
+		if got := intIDs(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("intIDs(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// DefaultMatchupMinGames 对位数据默认的最小样本数
+const DefaultMatchupMinGames = 100
+
+// parseChampionFight 将championFight解析为每个位置下的对位数据
+// 上游每个位置下可能是数组，也可能是以对手ID为key的对象
+func parseChampionFight(heroID string, raw map[string]interface{}) map[string][]dto.ChampionFightItem {
+	result := make(map[string][]dto.ChampionFightItem)
+	for pos, data := range raw {
+		records := make([]interface{}, 0)
+		switch v := data.(type) {
+		case []interface{}:
+			records = v
+		case map[string]interface{}:
+			for opponent, r := range v {
+				if m, ok := r.(map[string]interface{}); ok {
+					if _, ok := m["championid2"]; !ok {
+						m["championid2"] = opponent
+					}
+				}
+				records = append(records, r)
+			}
+		}
+
+		for _, r := range records {
+			m, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			item := dto.ChampionFightItem{
+				Championid:  cast.ToString(m["championid"]),
+				Championid2: cast.ToString(m["championid2"]),
+				Lane:        pos,
+				Igamecnt:    cast.ToInt32(m["igamecnt"]),
+				Wincnt:      cast.ToInt32(m["wincnt"]),
+				Winrate:     cast.ToInt32(m["winrate"]),
+			}
+			if item.Championid == "" {
+				item.Championid = heroID
+			}
+			if item.Championid2 == "" || item.Championid2 == heroID {
+				continue
+			}
+			if item.Winrate == 0 && item.Igamecnt > 0 {
+				item.Winrate = item.Wincnt * 10000 / item.Igamecnt
+			}
+			result[pos] = append(result[pos], item)
+		}
+	}
+	return result
+}
+
+// updateHeroesMatchup 按版本记录英雄的对位数据
+func updateHeroesMatchup(ctx *context.Context, platform int, heroId string, fightData *dto.ChampionFightData) error {
+	matchups := make([]*model.HeroesMatchup, 0)
+	for pos, items := range parseChampionFight(heroId, fightData.List.ChampionFight) {
+		for _, item := range items {
+			matchups = append(matchups, &model.HeroesMatchup{
+				HeroId:     heroId,
+				Pos:        pos,
+				OpponentId: item.Championid2,
+				Igamecnt:   item.Igamecnt,
+				Wincnt:     item.Wincnt,
+				Winrate:    item.Winrate,
+				Platform:   platform,
+				Version:    fightData.GameVer,
+				FileTime:   fightData.Date,
+			})
+		}
+	}
+	if len(matchups) == 0 {
+		log.Logger.Warn(ctx, "matchup is nil", "heroId:", heroId)
+		return nil
+	}
+
+	hmd := dao.NewHeroesMatchupDAO()
+	err := hmd.DeleteAndInsert(map[string]interface{}{
+		"heroId":   heroId,
+		"platform": platform,
+		"version":  fightData.GameVer,
+	}, matchups)
+	if err != nil {
+		return errors.New("Add HeroesMatchup " + err.Error() + ",heroId:" + heroId)
+	}
+	log.Logger.Info(ctx, "Add HeroesMatchup heroId:", heroId, "rows:", len(matchups))
+	return nil
+}
+
+// matchupVersion version为空时取库中最新的版本
+func matchupVersion(platform int, version string) (string, error) {
+	if version != "" {
+		return version, nil
+	}
+	v, err := dao.NewHeroesMatchupDAO().GetMaxVersion(platform)
+	if err != nil {
+		return "", err
+	}
+	if v == nil {
+		return "", errors.New("matchup data is empty")
+	}
+	return v.Version, nil
+}
+
+// GetHeroMatchup 英雄在某个位置上的优势对位和劣势对位
+func GetHeroMatchup(ctx *context.Context, platform int, heroID, pos, version string, minGames, limit int) (*dto.RespHeroMatchup, error) {
+	version, err := matchupVersion(platform, version)
+	if err != nil {
+		return nil, err
+	}
+	if minGames <= 0 {
+		minGames = DefaultMatchupMinGames
+	}
+
+	cond := map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+		"version":  version,
+	}
+	if pos != "" {
+		cond["pos"] = pos
+	}
+	hmd := dao.NewHeroesMatchupDAO()
+	best, err := hmd.FindMatchup(cond, minGames, true, limit)
+	if err != nil {
+		return nil, err
+	}
+	worst, err := hmd.FindMatchup(cond, minGames, false, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Logger.Info(ctx, fmt.Sprintf("matchup hero:%s pos:%s version:%s best:%d worst:%d", heroID, pos, version, len(best), len(worst)))
+	return &dto.RespHeroMatchup{
+		HeroId:  heroID,
+		Pos:     pos,
+		Version: version,
+		Best:    convMatchup(platform, best, false),
+		Worst:   convMatchup(platform, worst, false),
+	}, nil
+}
+
+// GetHeroCounter 克制某个英雄的英雄，即对位该英雄时胜率最高的英雄
+func GetHeroCounter(ctx *context.Context, platform int, heroID, pos, version string, minGames, limit int) (*dto.RespHeroCounter, error) {
+	version, err := matchupVersion(platform, version)
+	if err != nil {
+		return nil, err
+	}
+	if minGames <= 0 {
+		minGames = DefaultMatchupMinGames
+	}
+
+	cond := map[string]interface{}{
+		"opponentId": heroID,
+		"platform":   platform,
+		"version":    version,
+	}
+	if pos != "" {
+		cond["pos"] = pos
+	}
+	counters, err := dao.NewHeroesMatchupDAO().FindMatchup(cond, minGames, true, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Logger.Info(ctx, fmt.Sprintf("counter hero:%s pos:%s version:%s counters:%d", heroID, pos, version, len(counters)))
+	return &dto.RespHeroCounter{
+		HeroId:   heroID,
+		Pos:      pos,
+		Version:  version,
+		Counters: convMatchup(platform, counters, true),
+	}, nil
+}
+
+// convMatchup self为true时展示的是heroId本身，否则展示对手
+func convMatchup(platform int, rows []*model.HeroesMatchup, self bool) []*dto.Matchup {
+	result := make([]*dto.Matchup, 0, len(rows))
+	if len(rows) == 0 {
+		return result
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if self {
+			ids = append(ids, row.HeroId)
+		} else {
+			ids = append(ids, row.OpponentId)
+		}
+	}
+	heroes := heroBriefMap(platform, ids)
+
+	for i, row := range rows {
+		m := &dto.Matchup{
+			HeroId:   ids[i],
+			Pos:      row.Pos,
+			Games:    row.Igamecnt,
+			Wins:     row.Wincnt,
+			WinRate:  float64(row.Winrate) / 100,
+			Platform: platform,
+		}
+		if hero, ok := heroes[ids[i]]; ok {
+			m.Name = heroDisplayName(platform, hero)
+			m.Avatar = hero.Avatar
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// heroBriefMap 批量获取英雄名称和头像
+func heroBriefMap(platform int, ids []string) map[string]*model.HeroAttribute {
+	result := make(map[string]*model.HeroAttribute)
+	heroes, err := dao.NewHeroAttributeDAO().Find([]string{
+		"heroId", "name", "title", "avatar", "platform", "version",
+	}, map[string]interface{}{
+		"heroId":   ids,
+		"platform": platform,
+	})
+	if err != nil {
+		return result
+	}
+	for _, hero := range heroes {
+		result[hero.HeroId] = hero
+	}
+	return result
+}
+
+func heroDisplayName(platform int, hero *model.HeroAttribute) string {
+	if platform == common.PlatformForLOL {
+		return hero.Name + " " + hero.Title
+	}
+	return hero.Title + " " + hero.Name
+}
@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+)
+
+func TestParseChampionFight(t *testing.T) {
+	raw := map[string]interface{}{
+		// 数组形式
+		"top": []interface{}{
+			map[string]interface{}{"championid": "1", "championid2": "2", "igamecnt": "200", "wincnt": "110", "winrate": "5500"},
+			// 没有胜率时按场次计算
+			map[string]interface{}{"championid2": "3", "igamecnt": 100, "wincnt": 40},
+			// 对手是自己
+			map[string]interface{}{"championid2": "1", "igamecnt": 100},
+			"bad record",
+		},
+		// 以对手ID为key的对象
+		"mid": map[string]interface{}{
+			"4": map[string]interface{}{"igamecnt": 50, "wincnt": 30, "winrate": 6000},
+		},
+	}
+	result := parseChampionFight("1", raw)
+
+	top := result["top"]
+	if len(top) != 2 {
+		t.Fatalf("top len = %d, want 2", len(top))
+	}
+	if top[0].Championid2 != "2" || top[0].Winrate != 5500 || top[0].Lane != "top" {
+		t.Errorf("top[0] = %+v", top[0])
+	}
+	if top[1].Championid != "1" || top[1].Winrate != 4000 {
+		t.Errorf("top[1] = %+v", top[1])
+	}
+
+	mid := result["mid"]
+	if len(mid) != 1 || mid[0].Championid2 != "4" || mid[0].Winrate != 6000 {
+		t.Errorf("mid = %+v", mid)
+	}
+}
+
+func TestHeroDisplayName(t *testing.T) {
+	hero := &model.HeroAttribute{Name: "暗裔剑魔", Title: "亚托克斯"}
+	cases := []struct {
+		platform int
+		want     string
+	}{
+		{common.PlatformForLOL, "暗裔剑魔 亚托克斯"},
+		{common.PlatformForLOLM, "亚托克斯 暗裔剑魔"},
+	}
+	for _, c := range cases {
+		if got := heroDisplayName(c.platform, hero); got != c.want {
+			t.Errorf("heroDisplayName(%d) = %q, want %q", c.platform, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+	"whisper/pkg/utils"
+)
+
+// 改动的类型
+const (
+	PatchLabelBuff   = "buff"
+	PatchLabelNerf   = "nerf"
+	PatchLabelAdjust = "adjust"
+)
+
+const (
+	patchCategoryHero = "hero"
+	patchCategoryItem = "item"
+)
+
+const (
+	// DefaultPatchDays 版本前后各统计的天数
+	DefaultPatchDays = 7
+	// PatchSurpriseMinDelta 没有直接改动的英雄胜率变化超过该值（万分比）时认为是意外的变化
+	PatchSurpriseMinDelta = 100
+)
+
+var (
+	patchBuffWords = []string{"增强", "加强", "上调", "buff"}
+	patchNerfWords = []string{"削弱", "下调", "nerf"}
+	// patchInverseWords 数值越小越好的属性
+	patchInverseWords = []string{"冷却", "消耗", "法力值", "价格", "花费", "cd"}
+	// patchArrowRegex 数值变化，各等级的数值用 / 分隔，如 "80/120/160 → 90/130/170"
+	patchArrowRegex = regexp.MustCompile(`(\d+(?:\.\d+)?(?:\s*/\s*\d+(?:\.\d+)?)*)%?\s*(?:→|->|=>|⇒|➡)\s*(\d+(?:\.\d+)?(?:\s*/\s*\d+(?:\.\d+)?)*)`)
+	patchTagRegex   = regexp.MustCompile(`<[^>]+>`)
+)
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// sumPatchValues 各等级数值之和
+func sumPatchValues(s string) float64 {
+	var sum float64
+	for _, v := range strings.Split(s, "/") {
+		sum += cast.ToFloat64(strings.TrimSpace(v))
+	}
+	return sum
+}
+
+// labelPatchChange 判断改动是增强、削弱还是调整
+// 先看公告中的标签文字，没有时比较 "a → b" 形式的数值变化，冷却、消耗等数值变小算增强
+func labelPatchChange(tag, text string) string {
+	tag = strings.ToLower(tag)
+	switch {
+	case containsAny(tag, patchBuffWords):
+		return PatchLabelBuff
+	case containsAny(tag, patchNerfWords):
+		return PatchLabelNerf
+	}
+
+	score := 0
+	for _, line := range strings.Split(text, "\n") {
+		inverse := containsAny(strings.ToLower(line), patchInverseWords)
+		for _, m := range patchArrowRegex.FindAllStringSubmatch(line, -1) {
+			from, to := sumPatchValues(m[1]), sumPatchValues(m[2])
+			if from == to {
+				continue
+			}
+			if (to > from) != inverse {
+				score++
+			} else {
+				score--
+			}
+		}
+	}
+	switch {
+	case score > 0:
+		return PatchLabelBuff
+	case score < 0:
+		return PatchLabelNerf
+	}
+	return PatchLabelAdjust
+}
+
+// patchChangeText 改动的全部文字，去掉html标签
+func patchChangeText(item *dto.List) string {
+	texts := []string{item.Title, item.Descirbe, item.Content, item.AttachContent}
+	for _, sub := range item.List {
+		texts = append(texts, sub.Title, sub.Content)
+	}
+	s := strings.Join(texts, "\n")
+	s = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "</p>", "\n").Replace(s)
+	return patchTagRegex.ReplaceAllString(s, "")
+}
+
+// patchName 用于匹配公告标题的英雄或装备名称
+type patchName struct {
+	ID   string
+	Name string
+}
+
+// matchPatchName 公告标题对应的ID，完全相同的名称优先，其次是标题中包含的最长的名称
+func matchPatchName(names []patchName, title string) string {
+	var matched *patchName
+	for i := range names {
+		n := &names[i]
+		if len([]rune(n.Name)) < 2 {
+			continue
+		}
+		if n.Name == title {
+			return n.ID
+		}
+		if strings.Contains(title, n.Name) && (matched == nil || len(n.Name) > len(matched.Name)) {
+			matched = n
+		}
+	}
+	if matched == nil {
+		return ""
+	}
+	return matched.ID
+}
+
+// latestHeroNames 库中最新版本的英雄名称和称号
+func latestHeroNames(platform int) ([]patchName, error) {
+	heroes, err := dao.NewHeroAttributeDAO().Find([]string{
+		"heroId", "name", "title", "version",
+	}, map[string]interface{}{
+		"platform": platform,
+	})
+	if err != nil {
+		return nil, err
+	}
+	version := ""
+	for _, hero := range heroes {
+		if version == "" || utils.CompareVersion(hero.Version, version) > 0 {
+			version = hero.Version
+		}
+	}
+	names := make([]patchName, 0, len(heroes)*2)
+	for _, hero := range heroes {
+		if hero.Version != version {
+			continue
+		}
+		names = append(names, patchName{hero.HeroId, hero.Name}, patchName{hero.HeroId, hero.Title})
+	}
+	return names, nil
+}
+
+// currentEquipNames 当前版本的装备名称
+func currentEquipNames(platform int) ([]patchName, error) {
+	version, err := currentEquipVersion(platform)
+	if err != nil {
+		return nil, err
+	}
+	cond := map[string]interface{}{
+		"status":  0,
+		"version": version,
+	}
+	names := make([]patchName, 0)
+	if platform == common.PlatformForLOL {
+		equips, err := dao.NewLOLEquipmentDAO().Find([]string{"itemId", "name"}, cond)
+		if err != nil {
+			return nil, err
+		}
+		for _, equip := range equips {
+			names = append(names, patchName{equip.ItemId, equip.Name})
+		}
+		return names, nil
+	}
+	equips, err := dao.NewLOLMEquipmentDAO().Find([]string{"equipId", "name"}, cond)
+	if err != nil {
+		return nil, err
+	}
+	for _, equip := range equips {
+		names = append(names, patchName{equip.EquipId, equip.Name})
+	}
+	return names, nil
+}
+
+// parsePatchChanges 从版本公告中找出改动的英雄和装备
+func parsePatchChanges(platform int, details map[string]*dto.VersionDetail) ([]*dto.PatchChange, error) {
+	heroes, err := latestHeroNames(platform)
+	if err != nil {
+		return nil, err
+	}
+	items, err := currentEquipNames(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.PatchChange, 0)
+	exists := make(map[string]bool)
+	for _, detail := range details {
+		if detail == nil {
+			continue
+		}
+		for i := range detail.Data.List {
+			item := &detail.Data.List[i]
+			title := strings.TrimSpace(item.Title)
+			text := patchChangeText(item)
+			change := &dto.PatchChange{
+				Name:    title,
+				Label:   labelPatchChange(item.HeroTag+item.Descirbe, text),
+				Content: text,
+			}
+			switch {
+			case item.HeroId != "":
+				change.Category, change.ID = patchCategoryHero, item.HeroId
+			case item.ItemId != 0:
+				change.Category, change.ID = patchCategoryItem, cast.ToString(item.ItemId)
+			default:
+				if id := matchPatchName(heroes, title); id != "" {
+					change.Category, change.ID = patchCategoryHero, id
+				} else if id = matchPatchName(items, title); id != "" {
+					change.Category, change.ID = patchCategoryItem, id
+				}
+			}
+			if change.ID == "" || exists[change.Category+change.ID] {
+				continue
+			}
+			exists[change.Category+change.ID] = true
+			result = append(result, change)
+		}
+	}
+	return result, nil
+}
+
+// patchDate 版本的发布日期
+func patchDate(ctx *context.Context, platform int, vkey, id string) (string, error) {
+	list, err := GetVersionList(ctx, platform)
+	if err != nil && len(list) == 0 {
+		return "", err
+	}
+	for _, v := range list {
+		if (id != "" && v.Id == id) || (vkey != "" && v.Vkey == vkey) {
+			if v.PublicDate != "" {
+				return snapshotDate(v.PublicDate), nil
+			}
+		}
+	}
+	return "", errors.New("patch date not found")
+}
+
+type patchStat struct {
+	Win, Pick float64
+}
+
+// avgPositionStats 时间段内每个英雄、位置的平均胜率和出场率，key为 heroId_pos
+func avgPositionStats(rows []*model.HeroesPositionSnapshot) map[string]*patchStat {
+	sum := make(map[string]*patchStat)
+	count := make(map[string]float64)
+	for _, row := range rows {
+		key := row.HeroId + "_" + row.Pos
+		if _, ok := sum[key]; !ok {
+			sum[key] = &patchStat{}
+		}
+		sum[key].Win += float64(row.WinRate)
+		sum[key].Pick += float64(row.ShowRate)
+		count[key]++
+	}
+	for key, s := range sum {
+		s.Win /= count[key]
+		s.Pick /= count[key]
+	}
+	return sum
+}
+
+// avgItemStats 时间段内装备的平均胜率（按场次加权）和出场率（按英雄总场次加权），key为装备ID
+func avgItemStats(rows []*model.HeroesSuitSnapshot) map[string]*patchStat {
+	type acc struct{ win, games, pick, all float64 }
+	accs := make(map[string]*acc)
+	for _, row := range rows {
+		if strings.Contains(row.Itemids, ",") {
+			continue
+		}
+		a, ok := accs[row.Itemids]
+		if !ok {
+			a = &acc{}
+			accs[row.Itemids] = a
+		}
+		a.win += float64(row.Winrate) * float64(row.Igamecnt)
+		a.games += float64(row.Igamecnt)
+		a.pick += float64(row.Showrate) * float64(row.Allcnt)
+		a.all += float64(row.Allcnt)
+	}
+	result := make(map[string]*patchStat)
+	for id, a := range accs {
+		s := &patchStat{}
+		if a.games > 0 {
+			s.Win = a.win / a.games
+		}
+		if a.all > 0 {
+			s.Pick = a.pick / a.all
+		}
+		result[id] = s
+	}
+	return result
+}
+
+func newPatchMover(id, pos, label string, before, after *patchStat) *dto.PatchMover {
+	return &dto.PatchMover{
+		ID:         id,
+		Pos:        pos,
+		Label:      label,
+		WinBefore:  round2(before.Win / 100),
+		WinAfter:   round2(after.Win / 100),
+		WinDelta:   round2((after.Win - before.Win) / 100),
+		PickBefore: round2(before.Pick / 100),
+		PickAfter:  round2(after.Pick / 100),
+		PickDelta:  round2((after.Pick - before.Pick) / 100),
+	}
+}
+
+func sortPatchMovers(movers []*dto.PatchMover) {
+	sort.Slice(movers, func(i, j int) bool {
+		return math.Abs(movers[i].WinDelta) > math.Abs(movers[j].WinDelta)
+	})
+}
+
+type PatchImpactParams struct {
+	Platform int
+	Vkey     string
+	ID       string
+	Date     string // 版本发布日期，为空时从版本列表中获取
+	Tier     int
+	Days     int
+	Limit    int
+}
+
+// PatchImpact 版本改动前后英雄、装备胜率和出场率的变化
+func PatchImpact(ctx *context.Context, p *PatchImpactParams) (*dto.RespPatchImpact, error) {
+	if p.Days <= 0 {
+		p.Days = DefaultPatchDays
+	}
+	date := p.Date
+	if date == "" {
+		d, err := patchDate(ctx, p.Platform, p.Vkey, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		date = d
+	}
+	t, err := time.Parse(snapshotDateLayout, date)
+	if err != nil {
+		return nil, err
+	}
+	before := dto.DateRange{
+		From: t.AddDate(0, 0, -p.Days).Format(snapshotDateLayout),
+		To:   t.AddDate(0, 0, -1).Format(snapshotDateLayout),
+	}
+	after := dto.DateRange{
+		From: date,
+		To:   t.AddDate(0, 0, p.Days-1).Format(snapshotDateLayout),
+	}
+
+	details, err := VersionDetail(ctx, p.Platform, p.Vkey, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := parsePatchChanges(p.Platform, details)
+	if err != nil {
+		return nil, err
+	}
+	heroLabel := make(map[string]string)
+	itemLabel := make(map[string]string)
+	for _, c := range changes {
+		if c.Category == patchCategoryHero {
+			heroLabel[c.ID] = c.Label
+		} else {
+			itemLabel[c.ID] = c.Label
+		}
+	}
+
+	resp := &dto.RespPatchImpact{
+		Platform:   p.Platform,
+		Vkey:       p.Vkey,
+		PatchDate:  date,
+		Before:     before,
+		After:      after,
+		Heroes:     make([]*dto.PatchMover, 0),
+		Items:      make([]*dto.PatchMover, 0),
+		Surprising: make([]*dto.PatchMover, 0),
+		Changes:    changes,
+	}
+
+	// 英雄
+	cond := map[string]interface{}{
+		"platform": p.Platform,
+		"level":    tierLevel(p.Platform, p.Tier),
+	}
+	hpsd := dao.NewHeroesPositionSnapshotDAO()
+	rows, err := hpsd.Find(cond, before.From, before.To)
+	if err != nil {
+		return nil, err
+	}
+	heroBefore := avgPositionStats(rows)
+	rows, err = hpsd.Find(cond, after.From, after.To)
+	if err != nil {
+		return nil, err
+	}
+	heroAfter := avgPositionStats(rows)
+
+	heroIDs := make([]string, 0)
+	for key, a := range heroAfter {
+		b, ok := heroBefore[key]
+		if !ok {
+			continue
+		}
+		heroID, pos, _ := strings.Cut(key, "_")
+		label, changed := heroLabel[heroID]
+		m := newPatchMover(heroID, pos, label, b, a)
+		if changed {
+			resp.Heroes = append(resp.Heroes, m)
+		} else if math.Abs(a.Win-b.Win) >= PatchSurpriseMinDelta {
+			resp.Surprising = append(resp.Surprising, m)
+		} else {
+			continue
+		}
+		heroIDs = append(heroIDs, heroID)
+	}
+	sortPatchMovers(resp.Heroes)
+	sortPatchMovers(resp.Surprising)
+	if p.Limit > 0 && len(resp.Surprising) > p.Limit {
+		resp.Surprising = resp.Surprising[:p.Limit]
+	}
+	heroes := heroBriefMap(p.Platform, heroIDs)
+	for _, m := range append(resp.Heroes, resp.Surprising...) {
+		if hero, ok := heroes[m.ID]; ok {
+			m.Name = heroDisplayName(p.Platform, hero)
+			m.Icon = hero.Avatar
+		}
+	}
+
+	// 装备，只有LOL有统计数据
+	if p.Platform == common.PlatformForLOL && len(itemLabel) > 0 {
+		cond = map[string]interface{}{
+			"platform": p.Platform,
+			"type":     new(model.HeroesSuit).TypeOther(),
+		}
+		hssd := dao.NewHeroesSuitSnapshotDAO()
+		suitBefore, err := hssd.Find(cond, before.From, before.To)
+		if err != nil {
+			return nil, err
+		}
+		suitAfter, err := hssd.Find(cond, after.From, after.To)
+		if err != nil {
+			return nil, err
+		}
+		itemBefore, itemAfter := avgItemStats(suitBefore), avgItemStats(suitAfter)
+		for id, label := range itemLabel {
+			b, ok1 := itemBefore[id]
+			a, ok2 := itemAfter[id]
+			if !ok1 || !ok2 {
+				continue
+			}
+			resp.Items = append(resp.Items, newPatchMover(id, "", label, b, a))
+		}
+		sortPatchMovers(resp.Items)
+		for _, c := range changes {
+			for _, m := range resp.Items {
+				if c.Category == patchCategoryItem && c.ID == m.ID {
+					m.Name = c.Name
+				}
+			}
+		}
+	}
+
+	log.Logger.Info(ctx, fmt.Sprintf("patch impact platform:%d vkey:%s date:%s changes:%d heroes:%d items:%d surprising:%d",
+		p.Platform, p.Vkey, date, len(changes), len(resp.Heroes), len(resp.Items), len(resp.Surprising)))
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"math"
+	"testing"
+	"whisper/internal/dto"
+	"whisper/internal/model"
+)
+
+func TestLabelPatchChange(t *testing.T) {
+	cases := []struct {
+		name string
+		tag  string
+		text string
+		want string
+	}{
+		{"buff tag", "增强", "", PatchLabelBuff},
+		{"nerf tag", "NERF", "伤害：80 → 100", PatchLabelNerf},
+		{"value up", "", "基础伤害：80/120/160 → 90/130/170\n护甲：30 → 32", PatchLabelBuff},
+		{"value down", "", "攻击力：60 -> 55", PatchLabelNerf},
+		// 冷却、消耗等数值变小算增强
+		{"cooldown down", "", "冷却时间：12 → 10", PatchLabelBuff},
+		{"rank cooldown down", "", "冷却时间：12/11/10 → 10 / 9 / 8 秒", PatchLabelBuff},
+		{"cost up", "", "法力值消耗：50 → 60", PatchLabelNerf},
+		{"mixed", "", "伤害：80 → 100\n冷却时间：10 → 12", PatchLabelAdjust},
+		{"no numbers", "", "修复了技能描述", PatchLabelAdjust},
+		{"same value", "", "伤害：80 → 80", PatchLabelAdjust},
+	}
+	for _, c := range cases {
+		if got := labelPatchChange(c.tag, c.text); got != c.want {
+			t.Errorf("%s: labelPatchChange = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPatchChangeText(t *testing.T) {
+	item := &dto.List{
+		Title:   "安妮",
+		Content: "<p>Q 伤害：<b>80</b> → 90</p>",
+	}
+	item.List = append(item.List, dto.DetailList{Title: "W", Content: "冷却<br>10 → 8"})
+	want := "安妮\n\nQ 伤害：80 → 90\n\n\nW\n冷却\n10 → 8"
+	if got := patchChangeText(item); got != want {
+		t.Errorf("patchChangeText = %q, want %q", got, want)
+	}
+}
+
+func TestMatchPatchName(t *testing.T) {
+	names := []patchName{
+		{"1", "黑暗之女"},
+		{"1", "安妮"},
+		{"103", "九尾妖狐"},
+		{"103", "阿狸"},
+		{"3031", "无尽之刃"},
+		{"1038", "暴风大剑"},
+		{"6671", "狂风"},
+		{"6672", "狂风之力"},
+		{"9", "刀"},
+	}
+	cases := []struct {
+		title string
+		want  string
+	}{
+		{"安妮", "1"},
+		{"黑暗之女 安妮", "1"},
+		{"无尽之刃", "3031"},
+		// 优先最长的名称
+		{"狂风之力（已移除）", "6672"},
+		{"狂风", "6671"},
+		// 少于两个字的名称不参与匹配
+		{"刀妹", ""},
+		{"系统调整", ""},
+	}
+	for _, c := range cases {
+		if got := matchPatchName(names, c.title); got != c.want {
+			t.Errorf("matchPatchName(%q) = %q, want %q", c.title, got, c.want)
+		}
+	}
+}
+
+func TestAvgPositionStats(t *testing.T) {
+	rows := []*model.HeroesPositionSnapshot{
+		{HeroId: "1", Pos: "mid", WinRate: 5000, ShowRate: 100},
+		{HeroId: "1", Pos: "mid", WinRate: 5200, ShowRate: 300},
+		{HeroId: "1", Pos: "top", WinRate: 4800, ShowRate: 50},
+	}
+	got := avgPositionStats(rows)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if s := got["1_mid"]; s.Win != 5100 || s.Pick != 200 {
+		t.Errorf("1_mid = %+v, want {5100 200}", s)
+	}
+	if s := got["1_top"]; s.Win != 4800 || s.Pick != 50 {
+		t.Errorf("1_top = %+v, want {4800 50}", s)
+	}
+}
+
+func TestAvgItemStats(t *testing.T) {
+	rows := []*model.HeroesSuitSnapshot{
+		{Itemids: "3031", Winrate: 5000, Igamecnt: 300, Showrate: 1000, Allcnt: 1000},
+		{Itemids: "3031", Winrate: 5400, Igamecnt: 100, Showrate: 2000, Allcnt: 3000},
+		// 多件装备的组合不统计
+		{Itemids: "3031,1038", Winrate: 9000, Igamecnt: 1000},
+		{Itemids: "1038"},
+	}
+	got := avgItemStats(rows)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if s := got["3031"]; math.Abs(s.Win-5100) > 1e-9 || math.Abs(s.Pick-1750) > 1e-9 {
+		t.Errorf("3031 = %+v, want {5100 1750}", s)
+	}
+	if s := got["1038"]; s.Win != 0 || s.Pick != 0 {
+		t.Errorf("1038 = %+v, want zero", s)
+	}
+}
+
+func TestSortPatchMovers(t *testing.T) {
+	movers := []*dto.PatchMover{{ID: "a", WinDelta: 0.5}, {ID: "b", WinDelta: -2}, {ID: "c", WinDelta: 1}}
+	sortPatchMovers(movers)
+	if movers[0].ID != "b" || movers[1].ID != "c" || movers[2].ID != "a" {
+		t.Errorf("sortPatchMovers should order by absolute win delta, got %s %s %s", movers[0].ID, movers[1].ID, movers[2].ID)
+	}
+}
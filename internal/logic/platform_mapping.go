@@ -0,0 +1,406 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+	"whisper/pkg/utils"
+)
+
+// MinMappingSimilarity 自动匹配时名称相似度的最小值（万分比）
+const MinMappingSimilarity = 6000
+
+// MappingTypes 支持跨平台对应的实体类型，与对比的类型相同
+var MappingTypes = []string{CompareTypeHero, CompareTypeEquip, CompareTypeRune, CompareTypeSkill}
+
+// mappingEntity 用于匹配的实体，Names 为可以用来匹配的所有名称（如英雄的名字和称号）
+type mappingEntity struct {
+	ID    string
+	Name  string
+	Icon  string
+	Names []string
+}
+
+// loadMappingEntities 某个平台下最新版本的所有实体
+func loadMappingEntities(typ string, platform int) ([]*mappingEntity, error) {
+	result := make([]*mappingEntity, 0)
+	exists := make(map[string]bool)
+	add := func(id, name, icon string, names ...string) {
+		if id == "" || name == "" || exists[id] {
+			return
+		}
+		exists[id] = true
+		result = append(result, &mappingEntity{ID: id, Name: name, Icon: icon, Names: append([]string{name}, names...)})
+	}
+
+	switch typ {
+	case CompareTypeHero:
+		heroes, err := dao.NewHeroAttributeDAO().Find([]string{
+			"heroId", "name", "title", "avatar", "platform", "version",
+		}, map[string]interface{}{
+			"platform": platform,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, hero := range heroes {
+			add(hero.HeroId, heroDisplayName(platform, hero), hero.Avatar, hero.Name, hero.Title)
+		}
+	case CompareTypeEquip:
+		version, err := currentEquipVersion(platform)
+		if err != nil {
+			return nil, err
+		}
+		items, err := loadRoadmapItems(platform, version, nil)
+		if err != nil {
+			return nil, err
+		}
+		for id, item := range items {
+			add(id, item.Name, item.Icon)
+		}
+	case CompareTypeRune:
+		if platform == common.PlatformForLOL {
+			runes, err := dao.NewLOLRuneDAO().Find(nil, map[string]interface{}{"status": 0})
+			if err != nil {
+				return nil, err
+			}
+			// 同一个符文有多个版本时取最新的
+			sort.Slice(runes, func(i, j int) bool {
+				return utils.CompareVersion(runes[i].Version, runes[j].Version) > 0
+			})
+			for _, r := range runes {
+				add(r.RuneID, r.Name, r.Icon)
+			}
+		} else {
+			mrd := dao.NewLOLMRuneDAO()
+			v, err := mrd.GetLOLMRuneMaxVersion()
+			if err != nil {
+				return nil, err
+			}
+			runes, err := mrd.GetLOLMRune(v.Version)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range runes {
+				add(r.RuneId, r.Name, r.IconPath)
+			}
+		}
+	case CompareTypeSkill:
+		if platform == common.PlatformForLOL {
+			skills, err := dao.NewLOLSkillDAO().Find(nil, map[string]interface{}{"status": 0})
+			if err != nil {
+				return nil, err
+			}
+			sort.Slice(skills, func(i, j int) bool {
+				return utils.CompareVersion(skills[i].Version, skills[j].Version) > 0
+			})
+			for _, s := range skills {
+				add(s.SkillID, s.Name, s.Icon)
+			}
+		} else {
+			skills, err := dao.NewLOLMSkillDAO().Find(nil, map[string]interface{}{"status": 0})
+			if err != nil {
+				return nil, err
+			}
+			sort.Slice(skills, func(i, j int) bool {
+				return utils.CompareVersion(skills[i].Version, skills[j].Version) > 0
+			})
+			for _, s := range skills {
+				add(s.SkillID, s.Name, s.IconPath)
+			}
+		}
+	default:
+		return nil, errors.New("unknown mapping type " + typ)
+	}
+	return result, nil
+}
+
+func normMappingName(s string) []rune {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "", "·", "", "-", "", "'", "").Replace(s)
+	return []rune(s)
+}
+
+// levenshtein 编辑距离
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j] + 1
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if prev[j-1]+cost < cur[j] {
+				cur[j] = prev[j-1] + cost
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// nameSimilarity 名称相似度（万分比），相同为10000，一个包含另一个时至少为8000
+func nameSimilarity(a, b string) int32 {
+	ra, rb := normMappingName(a), normMappingName(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	if string(ra) == string(rb) {
+		return 10000
+	}
+	short, long := ra, rb
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	sim := 10000 - int32(levenshtein(ra, rb)*10000/len(long))
+	if len(short) >= 2 && strings.Contains(string(long), string(short)) && sim < 8000 {
+		sim = 8000
+	}
+	return sim
+}
+
+// entitySimilarity 两个实体所有名称两两比较的最大相似度
+func entitySimilarity(a, b *mappingEntity) int32 {
+	var sim int32
+	for _, na := range a.Names {
+		for _, nb := range b.Names {
+			if s := nameSimilarity(na, nb); s > sim {
+				sim = s
+			}
+		}
+	}
+	return sim
+}
+
+// suggestMappings 按相似度从高到低贪心匹配，每个实体最多匹配一次
+func suggestMappings(typ string, lol, lolm []*mappingEntity) []*model.PlatformMapping {
+	type candidate struct {
+		lol, lolm *mappingEntity
+		sim       int32
+	}
+	candidates := make([]*candidate, 0)
+	for _, a := range lol {
+		for _, b := range lolm {
+			if sim := entitySimilarity(a, b); sim >= MinMappingSimilarity {
+				candidates = append(candidates, &candidate{a, b, sim})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].sim > candidates[j].sim
+	})
+
+	var m model.PlatformMapping
+	usedLOL := make(map[string]bool)
+	usedLOLM := make(map[string]bool)
+	result := make([]*model.PlatformMapping, 0)
+	for _, c := range candidates {
+		if usedLOL[c.lol.ID] || usedLOLM[c.lolm.ID] {
+			continue
+		}
+		usedLOL[c.lol.ID], usedLOLM[c.lolm.ID] = true, true
+		result = append(result, &model.PlatformMapping{
+			Type:       typ,
+			LolId:      c.lol.ID,
+			LolName:    c.lol.Name,
+			LolmId:     c.lolm.ID,
+			LolmName:   c.lolm.Name,
+			Similarity: c.sim,
+			Source:     m.SourceAuto(),
+		})
+	}
+	return result
+}
+
+// SuggestPlatformMapping 按名称重新自动匹配，手动指定的对应关系保持不变，typ为空时处理所有类型
+func SuggestPlatformMapping(ctx *context.Context, typ string) ([]*dto.MappingSummary, error) {
+	types := MappingTypes
+	if typ != "" {
+		types = []string{typ}
+	}
+
+	var m model.PlatformMapping
+	pmd := dao.NewPlatformMappingDAO()
+	result := make([]*dto.MappingSummary, 0, len(types))
+	for _, t := range types {
+		lol, err := loadMappingEntities(t, common.PlatformForLOL)
+		if err != nil {
+			return nil, err
+		}
+		lolm, err := loadMappingEntities(t, common.PlatformForLOLM)
+		if err != nil {
+			return nil, err
+		}
+		manual, err := pmd.Find(map[string]interface{}{"type": t, "source": m.SourceManual()})
+		if err != nil {
+			return nil, err
+		}
+
+		// 已经手动指定的不参与自动匹配
+		fixedLOL := make(map[string]bool)
+		fixedLOLM := make(map[string]bool)
+		for _, row := range manual {
+			fixedLOL[row.LolId] = true
+			fixedLOLM[row.LolmId] = true
+		}
+		freeLOL := make([]*mappingEntity, 0, len(lol))
+		for _, e := range lol {
+			if !fixedLOL[e.ID] {
+				freeLOL = append(freeLOL, e)
+			}
+		}
+		freeLOLM := make([]*mappingEntity, 0, len(lolm))
+		for _, e := range lolm {
+			if !fixedLOLM[e.ID] {
+				freeLOLM = append(freeLOLM, e)
+			}
+		}
+
+		rows := suggestMappings(t, freeLOL, freeLOLM)
+		err = pmd.DeleteAndInsert(map[string]interface{}{"type": t, "source": m.SourceAuto()}, rows)
+		if err != nil {
+			return nil, errors.New("Add PlatformMapping " + err.Error())
+		}
+
+		summary := &dto.MappingSummary{
+			Type:          t,
+			Auto:          len(rows),
+			Manual:        len(manual),
+			UnmatchedLOL:  len(freeLOL) - len(rows),
+			UnmatchedLOLM: len(freeLOLM) - len(rows),
+		}
+		log.Logger.Info(ctx, fmt.Sprintf("platform mapping type:%s auto:%d manual:%d unmatched lol:%d lolm:%d",
+			t, summary.Auto, summary.Manual, summary.UnmatchedLOL, summary.UnmatchedLOLM))
+		result = append(result, summary)
+	}
+	return result, nil
+}
+
+func findMappingEntity(entities []*mappingEntity, id string) *mappingEntity {
+	for _, e := range entities {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// OverridePlatformMapping 手动指定对应关系，lolmID为空表示该实体在LOLM中没有对应
+func OverridePlatformMapping(ctx *context.Context, typ, lolID, lolmID string) (*dto.RespPlatformMapping, error) {
+	lol, err := loadMappingEntities(typ, common.PlatformForLOL)
+	if err != nil {
+		return nil, err
+	}
+	a := findMappingEntity(lol, lolID)
+	if a == nil {
+		return nil, errors.New("can not find lol " + typ + " " + lolID)
+	}
+
+	var m model.PlatformMapping
+	row := &model.PlatformMapping{
+		Type:    typ,
+		LolId:   a.ID,
+		LolName: a.Name,
+		Source:  m.SourceManual(),
+	}
+	if lolmID != "" {
+		lolm, err := loadMappingEntities(typ, common.PlatformForLOLM)
+		if err != nil {
+			return nil, err
+		}
+		b := findMappingEntity(lolm, lolmID)
+		if b == nil {
+			return nil, errors.New("can not find lolm " + typ + " " + lolmID)
+		}
+		row.LolmId, row.LolmName = b.ID, b.Name
+		row.Similarity = entitySimilarity(a, b)
+	}
+	if err = dao.NewPlatformMappingDAO().Override(row); err != nil {
+		return nil, err
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("override platform mapping type:%s lol:%s lolm:%s", typ, lolID, lolmID))
+	if lolmID == "" {
+		// 指定为没有对应的实体，GetOtherPlatform查不到，直接用保存的记录返回
+		resp := platformMappingResp(row, common.PlatformForLOL)
+		resp.From.Icon = a.Icon
+		return resp, nil
+	}
+	return GetOtherPlatform(ctx, typ, common.PlatformForLOL, lolID)
+}
+
+// GetOtherPlatform 实体在另一个平台上对应的实体，手动指定的优先
+func GetOtherPlatform(ctx *context.Context, typ string, platform int, id string) (*dto.RespPlatformMapping, error) {
+	cond := map[string]interface{}{"type": typ, "lolId": id}
+	other := common.PlatformForLOLM
+	if platform == common.PlatformForLOLM {
+		cond = map[string]interface{}{"type": typ, "lolmId": id}
+		other = common.PlatformForLOL
+	}
+	rows, err := dao.NewPlatformMappingDAO().Find(cond)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || rows[0].LolmId == "" {
+		return nil, errors.New(fmt.Sprintf("%s %s has no mapping on the other platform", typ, id))
+	}
+	resp := platformMappingResp(rows[0], platform)
+	entities, err := loadMappingEntities(typ, other)
+	if err != nil {
+		log.Logger.Warn(ctx, "loadMappingEntities:", err)
+	} else if e := findMappingEntity(entities, resp.To.ID); e != nil {
+		resp.To.Name, resp.To.Icon = e.Name, e.Icon
+	}
+	return resp, nil
+}
+
+// platformMappingResp 映射记录转为从platform出发的结果，没有对应的LOLM实体时To为空
+func platformMappingResp(row *model.PlatformMapping, platform int) *dto.RespPlatformMapping {
+	var m model.PlatformMapping
+	from := &dto.MappingEntity{Platform: common.PlatformForLOL, ID: row.LolId, Name: row.LolName}
+	var to *dto.MappingEntity
+	if row.LolmId != "" {
+		to = &dto.MappingEntity{Platform: common.PlatformForLOLM, ID: row.LolmId, Name: row.LolmName}
+	}
+	if platform == common.PlatformForLOLM {
+		from, to = to, from
+	}
+	return &dto.RespPlatformMapping{
+		Type:       row.Type,
+		From:       from,
+		To:         to,
+		Similarity: round2(float64(row.Similarity) / 100),
+		Manual:     row.Source == m.SourceManual(),
+	}
+}
+
+// ComparePlatforms 同一个实体在两个平台上的对比，LOL在前
+func ComparePlatforms(ctx *context.Context, typ string, platform int, id string) (*dto.RespCompare, error) {
+	mapping, err := GetOtherPlatform(ctx, typ, platform, id)
+	if err != nil {
+		return nil, err
+	}
+	params := []*CompareParams{
+		{ID: mapping.From.ID, Platform: mapping.From.Platform},
+		{ID: mapping.To.ID, Platform: mapping.To.Platform},
+	}
+	if platform == common.PlatformForLOLM {
+		params[0], params[1] = params[1], params[0]
+	}
+	return Compare(ctx, typ, params)
+}
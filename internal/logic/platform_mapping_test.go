@@ -0,0 +1,116 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+)
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"无尽之刃", "无尽之刃", 0},
+		{"无尽之刃", "无尽战刃", 1},
+	}
+	for _, c := range cases {
+		if got := levenshtein([]rune(c.a), []rune(c.b)); got != c.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int32
+	}{
+		{"", "安妮", 0},
+		{"安妮", "安妮", 10000},
+		// 忽略大小写、空格和间隔号
+		{"Kai'Sa", "kaisa", 10000},
+		{"卡莎·虚空之女", "卡莎 虚空之女", 10000},
+		{"无尽之刃", "无尽战刃", 7500},
+		// 一个包含另一个时至少为8000
+		{"狂风之力", "狂风之力（传说）", 8000},
+		{"安妮", "黑暗之女安妮", 8000},
+		// 只有一个字时不按包含处理
+		{"刀", "刀锋", 5000},
+		{"安妮", "阿狸", 0},
+	}
+	for _, c := range cases {
+		if got := nameSimilarity(c.a, c.b); got != c.want {
+			t.Errorf("nameSimilarity(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+		if got := nameSimilarity(c.b, c.a); got != c.want {
+			t.Errorf("nameSimilarity(%q, %q) = %d, want %d", c.b, c.a, got, c.want)
+		}
+	}
+}
+
+func TestEntitySimilarity(t *testing.T) {
+	a := &mappingEntity{ID: "1", Names: []string{"黑暗之女", "安妮"}}
+	b := &mappingEntity{ID: "10001", Names: []string{"安妮"}}
+	if got := entitySimilarity(a, b); got != 10000 {
+		t.Errorf("entitySimilarity = %d, want 10000", got)
+	}
+	if got := entitySimilarity(a, &mappingEntity{}); got != 0 {
+		t.Errorf("entitySimilarity without names = %d, want 0", got)
+	}
+}
+
+func TestSuggestMappings(t *testing.T) {
+	lol := []*mappingEntity{
+		{ID: "3031", Name: "无尽之刃", Names: []string{"无尽之刃"}},
+		{ID: "3032", Name: "无尽战刃", Names: []string{"无尽战刃"}},
+		{ID: "1001", Name: "鞋子", Names: []string{"鞋子"}},
+	}
+	lolm := []*mappingEntity{
+		{ID: "6031", Name: "无尽战刃", Names: []string{"无尽战刃"}},
+		{ID: "6032", Name: "无尽之刃", Names: []string{"无尽之刃"}},
+		{ID: "6099", Name: "中亚沙漏", Names: []string{"中亚沙漏"}},
+	}
+	result := suggestMappings(CompareTypeEquip, lol, lolm)
+	got := make(map[string]string)
+	for _, m := range result {
+		got[m.LolId] = m.LolmId
+		if m.Type != CompareTypeEquip || m.Similarity < MinMappingSimilarity {
+			t.Errorf("unexpected mapping %+v", m)
+		}
+	}
+	// 完全相同的名称优先，每个实体只匹配一次，相似度不够的不匹配
+	want := map[string]string{"3031": "6032", "3032": "6031"}
+	if len(got) != len(want) || got["3031"] != want["3031"] || got["3032"] != want["3032"] {
+		t.Errorf("suggestMappings = %v, want %v", got, want)
+	}
+}
+
+func TestFindMappingEntity(t *testing.T) {
+	entities := []*mappingEntity{{ID: "1"}, {ID: "2"}}
+	if got := findMappingEntity(entities, "2"); got == nil || got.ID != "2" {
+		t.Errorf("findMappingEntity(2) = %+v", got)
+	}
+	if got := findMappingEntity(entities, "3"); got != nil {
+		t.Errorf("findMappingEntity(3) = %+v, want nil", got)
+	}
+}
+
+func TestPlatformMappingResp(t *testing.T) {
+	row := &model.PlatformMapping{Type: CompareTypeEquip, LolId: "3031", LolName: "无尽之刃", LolmId: "6032", LolmName: "无尽之刃", Similarity: 10000}
+	resp := platformMappingResp(row, common.PlatformForLOLM)
+	if resp.From.ID != "6032" || resp.From.Platform != common.PlatformForLOLM || resp.To.ID != "3031" || resp.Similarity != 100 || resp.Manual {
+		t.Errorf("platformMappingResp(lolm) = %+v", resp)
+	}
+
+	// 手动指定没有对应时To为空
+	var m model.PlatformMapping
+	row = &model.PlatformMapping{Type: CompareTypeEquip, LolId: "3031", LolName: "无尽之刃", Source: m.SourceManual()}
+	resp = platformMappingResp(row, common.PlatformForLOL)
+	if resp.From == nil || resp.From.ID != "3031" || resp.To != nil || !resp.Manual || resp.Type != CompareTypeEquip {
+		t.Errorf("platformMappingResp(no counterpart) = %+v", resp)
+	}
+}
@@ -0,0 +1,384 @@
+package logic
+
+import (
+	errors2 "errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// roadmapItem 合成路线中的装备，From 中保留重复的配件
+type roadmapItem struct {
+	dto.Roadmap
+	From     []string
+	Into     []string
+	Tags     []string
+	Stats    map[string]float64
+	Passives []string
+}
+
+// loadRoadmapItems 加载版本下所有装备，key 为装备ID
+func loadRoadmapItems(platform int, version string, maps []string) (map[string]*roadmapItem, error) {
+	items := make(map[string]*roadmapItem)
+	if platform == common.PlatformForLOL {
+		equips, err := dao.NewLOLEquipmentDAO().GetRoadmapEquips(version, maps)
+		if err != nil {
+			return nil, err
+		}
+		for _, equip := range equips {
+			// LOL 的装备按地图分多行存储，这里合并为一条：地图和可合成的装备取并集，
+			// 配方和价格各地图一致，取 id 最小的一行
+			if item, ok := items[equip.ItemId]; ok {
+				item.Maps += "," + equip.Maps
+				for _, id := range splitRoadmapIDs(equip.Into) {
+					if !inArray(id, item.Into) {
+						item.Into = append(item.Into, id)
+					}
+				}
+				continue
+			}
+			items[equip.ItemId] = &roadmapItem{
+				Roadmap: dto.Roadmap{
+					ID:        cast.ToInt(equip.ItemId),
+					Name:      equip.Name,
+					Icon:      equip.IconPath,
+					Maps:      equip.Maps,
+					Plaintext: equip.Plaintext,
+					Desc:      equip.Description,
+					Price:     cast.ToInt(equip.Total),
+					Sell:      cast.ToInt(equip.Sell),
+					Version:   equip.Version,
+					Platform:  common.PlatformForLOL,
+					Tier:      EquipTier(equip.From, equip.Into),
+				},
+				From:     splitRoadmapIDs(equip.From),
+				Into:     splitRoadmapIDs(equip.Into),
+				Tags:     splitTags(equip.Types + "," + equip.Tag),
+				Stats:    ParseLOLEquipStats(equip.Description),
+				Passives: ParseUniquePassives(equip.Description),
+			}
+		}
+		return items, nil
+	}
+
+	equips, err := dao.NewLOLMEquipmentDAO().GetRoadmapEquips(version)
+	if err != nil {
+		return nil, err
+	}
+	for _, equip := range equips {
+		if _, ok := items[equip.EquipId]; ok {
+			continue
+		}
+		items[equip.EquipId] = &roadmapItem{
+			Roadmap: dto.Roadmap{
+				ID:       cast.ToInt(equip.EquipId),
+				Name:     equip.Name,
+				Icon:     equip.IconPath,
+				Level:    equip.Level,
+				Desc:     equip.Description,
+				Price:    cast.ToInt(equip.Price),
+				Version:  equip.Version,
+				Platform: common.PlatformForLOLM,
+
+				Tier:         LOLMEquipTier(equip),
+				ComposeLevel: cast.ToInt(equip.ComposeLevel),
+			},
+			From:     splitRoadmapIDs(equip.From),
+			Into:     splitRoadmapIDs(equip.Into),
+			Tags:     splitTags(equip.Type + "," + equip.Tags + "," + equip.Level),
+			Stats:    LOLMEquipStats(equip),
+			Passives: ParseUniquePassives(equip.Description),
+		}
+	}
+	return items, nil
+}
+
+func splitRoadmapIDs(ids string) []string {
+	result := make([]string, 0)
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || id == "0" {
+			continue
+		}
+		result = append(result, id)
+	}
+	return result
+}
+
+func splitTags(tags string) []string {
+	result := make([]string, 0)
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// countRoadmapIDs 按出现顺序统计配件数量
+func countRoadmapIDs(ids []string) ([]string, map[string]int) {
+	order := make([]string, 0, len(ids))
+	count := make(map[string]int, len(ids))
+	for _, id := range ids {
+		if count[id] == 0 {
+			order = append(order, id)
+		}
+		count[id]++
+	}
+	return order, count
+}
+
+// buildRoadmapTree 向下递归构建合成树，path 用于防止数据异常时出现环
+func buildRoadmapTree(items map[string]*roadmapItem, id string, count int, path map[string]bool) *dto.RoadmapNode {
+	item, ok := items[id]
+	if !ok {
+		return nil
+	}
+	node := &dto.RoadmapNode{
+		Roadmap:  item.Roadmap,
+		Count:    count,
+		Children: make([]*dto.RoadmapNode, 0),
+	}
+	if path[id] {
+		node.CombineCost = item.Price
+		return node
+	}
+	path[id] = true
+	defer delete(path, id)
+
+	childPrice := 0
+	order, counts := countRoadmapIDs(item.From)
+	for _, fid := range order {
+		child := buildRoadmapTree(items, fid, counts[fid], path)
+		if child == nil {
+			continue
+		}
+		node.Children = append(node.Children, child)
+		childPrice += child.Price * child.Count
+	}
+
+	node.CombineCost = item.Price - childPrice
+	if node.CombineCost < 0 {
+		node.CombineCost = 0
+	}
+	return node
+}
+
+// roadmapTreeCost 合成树中所有合成费用之和
+func roadmapTreeCost(node *dto.RoadmapNode) int {
+	if node == nil {
+		return 0
+	}
+	cost := node.CombineCost
+	for _, child := range node.Children {
+		cost += roadmapTreeCost(child) * child.Count
+	}
+	return cost
+}
+
+// buildIntoTree 向上递归构建可合成的装备
+func buildIntoTree(items map[string]*roadmapItem, id string, path map[string]bool) []*dto.RoadmapNode {
+	item, ok := items[id]
+	if !ok || path[id] {
+		return nil
+	}
+	path[id] = true
+	defer delete(path, id)
+
+	result := make([]*dto.RoadmapNode, 0)
+	order, _ := countRoadmapIDs(item.Into)
+	for _, iid := range order {
+		into, ok := items[iid]
+		if !ok {
+			continue
+		}
+		// 合成 into 需要多少个当前装备
+		_, counts := countRoadmapIDs(into.From)
+		node := &dto.RoadmapNode{
+			Roadmap:  into.Roadmap,
+			Count:    counts[id],
+			Children: buildIntoTree(items, iid, path),
+		}
+		childPrice := 0
+		for _, fid := range into.From {
+			if f, ok := items[fid]; ok {
+				childPrice += f.Price
+			}
+		}
+		node.CombineCost = into.Price - childPrice
+		if node.CombineCost < 0 {
+			node.CombineCost = 0
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// currentEquipVersion 获取装备表当前生效的版本
+func currentEquipVersion(platform int) (string, error) {
+	if platform == common.PlatformForLOL {
+		v, err := dao.NewLOLEquipmentDAO().GetLOLEquipmentMaxVersion()
+		if err != nil || v == nil {
+			return "", err
+		}
+		return v.Version, nil
+	}
+	v, err := dao.NewLOLMEquipmentDAO().GetLOLMEquipmentMaxVersion()
+	if err != nil || v == nil {
+		return "", err
+	}
+	return v.Version, nil
+}
+
+// roadmapCombineCost 单个节点的合成费用
+func roadmapCombineCost(items map[string]*roadmapItem, item *roadmapItem) int {
+	childPrice := 0
+	for _, fid := range item.From {
+		if f, ok := items[fid]; ok {
+			childPrice += f.Price
+		}
+	}
+	if item.Price-childPrice < 0 {
+		return 0
+	}
+	return item.Price - childPrice
+}
+
+// roadmapRemainCost 计算合成 id 还需要的金币，inv 为已有装备及数量，会被扣减
+// 优先抵扣高一级的配件，visit 会收到每个未被抵扣的节点及其剩余花费
+func roadmapRemainCost(items map[string]*roadmapItem, id string, inv map[string]int, path map[string]bool,
+	visit func(id string, cost int)) (int, []int) {
+	item, ok := items[id]
+	if !ok {
+		return 0, nil
+	}
+	if inv[id] > 0 {
+		inv[id]--
+		return 0, []int{item.ID}
+	}
+	if path[id] {
+		return item.Price, nil
+	}
+	path[id] = true
+	defer delete(path, id)
+
+	cost := roadmapCombineCost(items, item)
+	used := make([]int, 0)
+	for _, fid := range item.From {
+		c, u := roadmapRemainCost(items, fid, inv, path, visit)
+		cost += c
+		used = append(used, u...)
+	}
+	if visit != nil {
+		visit(id, cost)
+	}
+	return cost, used
+}
+
+func copyInventory(inv map[string]int) map[string]int {
+	result := make(map[string]int, len(inv))
+	for k, v := range inv {
+		result[k] = v
+	}
+	return result
+}
+
+// EquipFinish 根据已有装备和金币，列出可以直接合成的成装，以及朝目标装备的下一步购买
+func EquipFinish(ctx *context.Context, platform int, version string, maps []string, owned []string, gold int, target string) (*dto.RespEquipFinish, error) {
+	var err error
+	if version == "" {
+		version, err = currentEquipVersion(platform)
+		if err != nil {
+			return nil, err
+		}
+	}
+	items, err := loadRoadmapItems(platform, version, maps)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := make(map[string]int, len(owned))
+	for _, id := range owned {
+		inv[id]++
+	}
+
+	resp := &dto.RespEquipFinish{
+		Gold:    gold,
+		Version: version,
+		Buyable: make([]*dto.EquipFinishItem, 0),
+	}
+
+	// 成装：有配件且不能再合成的装备
+	for id, item := range items {
+		if len(item.From) == 0 || len(item.Into) > 0 || inv[id] > 0 {
+			continue
+		}
+		cost, used := roadmapRemainCost(items, id, copyInventory(inv), map[string]bool{}, nil)
+		if cost > gold {
+			continue
+		}
+		resp.Buyable = append(resp.Buyable, &dto.EquipFinishItem{
+			Roadmap:    item.Roadmap,
+			RemainCost: cost,
+			UsedOwned:  used,
+			Affordable: true,
+		})
+	}
+	// 优先展示抵扣多的，其次是花费少的
+	sort.Slice(resp.Buyable, func(i, j int) bool {
+		if len(resp.Buyable[i].UsedOwned) != len(resp.Buyable[j].UsedOwned) {
+			return len(resp.Buyable[i].UsedOwned) > len(resp.Buyable[j].UsedOwned)
+		}
+		if resp.Buyable[i].RemainCost != resp.Buyable[j].RemainCost {
+			return resp.Buyable[i].RemainCost < resp.Buyable[j].RemainCost
+		}
+		return resp.Buyable[i].ID < resp.Buyable[j].ID
+	})
+
+	if target == "" {
+		return resp, nil
+	}
+	targetItem, ok := items[target]
+	if !ok {
+		return nil, errors2.New("target equip can not find data")
+	}
+
+	// 记录目标合成树中每个还未拥有的节点，找出最便宜的一步
+	nextID, nextCost := "", -1
+	cost, used := roadmapRemainCost(items, target, copyInventory(inv), map[string]bool{}, func(id string, c int) {
+		if id == target {
+			return
+		}
+		if nextCost < 0 || c < nextCost {
+			nextID, nextCost = id, c
+		}
+	})
+	resp.Target = &dto.EquipFinishItem{
+		Roadmap:    targetItem.Roadmap,
+		RemainCost: cost,
+		UsedOwned:  used,
+		Affordable: cost <= gold,
+	}
+
+	// 配件都已经有了，下一步就是直接合成目标装备
+	if nextID == "" {
+		if cost > 0 {
+			resp.NextComponent = resp.Target
+		}
+	} else {
+		resp.NextComponent = &dto.EquipFinishItem{
+			Roadmap:    items[nextID].Roadmap,
+			RemainCost: nextCost,
+			Affordable: nextCost <= gold,
+		}
+	}
+
+	log.Logger.Info(ctx, fmt.Sprintf("equip finish platform:%d version:%s owned:%v gold:%d buyable:%d", platform, version, owned, gold, len(resp.Buyable)))
+	return resp, nil
+}
@@ -0,0 +1,151 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/dto"
+)
+
+func newRoadmapItem(id int, name string, price int, from, into []string) *roadmapItem {
+	return &roadmapItem{
+		Roadmap: dto.Roadmap{ID: id, Name: name, Price: price},
+		From:    from,
+		Into:    into,
+	}
+}
+
+// testRoadmapItems 长剑 -> 考尔菲德的战锤(2把长剑) -> 黑色切割者(战锤+长剑)
+func testRoadmapItems() map[string]*roadmapItem {
+	return map[string]*roadmapItem{
+		"1036": newRoadmapItem(1036, "长剑", 350, nil, []string{"3133", "3071"}),
+		"3133": newRoadmapItem(3133, "考尔菲德的战锤", 1100, []string{"1036", "1036"}, []string{"3071"}),
+		"3071": newRoadmapItem(3071, "黑色切割者", 3000, []string{"3133", "1036"}, nil),
+	}
+}
+
+func TestSplitRoadmapIDs(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{"0", []string{}},
+		{"1036,1036", []string{"1036", "1036"}},
+		{" 3133 , ,0,1036", []string{"3133", "1036"}},
+	}
+	for _, c := range cases {
+		if got := splitRoadmapIDs(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("splitRoadmapIDs(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestCountRoadmapIDs(t *testing.T) {
+	order, count := countRoadmapIDs([]string{"1036", "3133", "1036"})
+	if !reflect.DeepEqual(order, []string{"1036", "3133"}) {
+		t.Errorf("order = %v", order)
+	}
+	if count["1036"] != 2 || count["3133"] != 1 {
+		t.Errorf("count = %v", count)
+	}
+}
+
+func TestBuildRoadmapTree(t *testing.T) {
+	items := testRoadmapItems()
+	cases := []struct {
+		id          string
+		children    int
+		combineCost int
+		treeCost    int
+	}{
+		{"1036", 0, 350, 350},
+		// 1100 - 2*350
+		{"3133", 1, 400, 400 + 2*350},
+		// 3000 - 1100 - 350，整棵树的花费等于售价
+		{"3071", 2, 1550, 3000},
+		{"9999", 0, 0, 0},
+	}
+	for _, c := range cases {
+		node := buildRoadmapTree(items, c.id, 1, map[string]bool{})
+		if c.id == "9999" {
+			if node != nil {
+				t.Errorf("buildRoadmapTree(%s) should be nil", c.id)
+			}
+			continue
+		}
+		if len(node.Children) != c.children || node.CombineCost != c.combineCost {
+			t.Errorf("buildRoadmapTree(%s) children=%d combineCost=%d, want %d %d",
+				c.id, len(node.Children), node.CombineCost, c.children, c.combineCost)
+		}
+		if got := roadmapTreeCost(node); got != c.treeCost {
+			t.Errorf("roadmapTreeCost(%s) = %d, want %d", c.id, got, c.treeCost)
+		}
+	}
+}
+
+func TestBuildRoadmapTreeCycle(t *testing.T) {
+	items := map[string]*roadmapItem{
+		"1": newRoadmapItem(1, "a", 100, []string{"2"}, nil),
+		"2": newRoadmapItem(2, "b", 50, []string{"1"}, nil),
+	}
+	node := buildRoadmapTree(items, "1", 1, map[string]bool{})
+	if node == nil || len(node.Children) != 1 || len(node.Children[0].Children) != 1 {
+		t.Fatalf("unexpected tree %+v", node)
+	}
+	if leaf := node.Children[0].Children[0]; len(leaf.Children) != 0 || leaf.CombineCost != 100 {
+		t.Errorf("cycle should stop at repeated node, got %+v", leaf)
+	}
+}
+
+func TestBuildIntoTree(t *testing.T) {
+	nodes := buildIntoTree(testRoadmapItems(), "1036", map[string]bool{})
+	if len(nodes) != 2 {
+		t.Fatalf("len = %d, want 2", len(nodes))
+	}
+	if nodes[0].ID != 3133 || nodes[0].Count != 2 || len(nodes[0].Children) != 1 {
+		t.Errorf("first into = %+v", nodes[0])
+	}
+	if nodes[1].ID != 3071 || nodes[1].Count != 1 || nodes[1].CombineCost != 1550 {
+		t.Errorf("second into = %+v", nodes[1])
+	}
+}
+
+func TestRoadmapRemainCost(t *testing.T) {
+	items := testRoadmapItems()
+	cases := []struct {
+		name   string
+		target string
+		inv    map[string]int
+		cost   int
+		used   []int
+		remain map[string]int
+	}{
+		{"nothing owned", "3071", map[string]int{}, 3000, []int{}, map[string]int{}},
+		{"one long sword", "3071", map[string]int{"1036": 1}, 2650, []int{1036}, map[string]int{"1036": 0}},
+		// 优先抵扣高一级的战锤，剩下的长剑用于最后一个配件
+		{"hammer and sword", "3071", map[string]int{"3133": 1, "1036": 2}, 1550, []int{3133, 1036}, map[string]int{"3133": 0, "1036": 1}},
+		{"target owned", "3133", map[string]int{"3133": 1}, 0, []int{3133}, map[string]int{"3133": 0}},
+		{"unknown item", "9999", map[string]int{"1036": 1}, 0, nil, map[string]int{"1036": 1}},
+	}
+	for _, c := range cases {
+		cost, used := roadmapRemainCost(items, c.target, c.inv, map[string]bool{}, nil)
+		if cost != c.cost {
+			t.Errorf("%s: cost = %d, want %d", c.name, cost, c.cost)
+		}
+		if !reflect.DeepEqual(used, c.used) {
+			t.Errorf("%s: used = %v, want %v", c.name, used, c.used)
+		}
+		if !reflect.DeepEqual(c.inv, c.remain) {
+			t.Errorf("%s: inventory = %v, want %v", c.name, c.inv, c.remain)
+		}
+	}
+}
+
+func TestCopyInventory(t *testing.T) {
+	inv := map[string]int{"1036": 2}
+	cp := copyInventory(inv)
+	cp["1036"]--
+	if inv["1036"] != 2 {
+		t.Errorf("copyInventory should not share the map")
+	}
+}
@@ -0,0 +1,252 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// DefaultRotationWeeks 周免日历默认查询的周数
+const DefaultRotationWeeks = 12
+
+// rotationWeek t 所在周的周一
+func rotationWeek(t time.Time) string {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset).Format(snapshotDateLayout)
+}
+
+// rotationWeekOf 周免所属的周，按上游文件时间计算，文件时间缺失或无法解析时按 now 计算
+func rotationWeekOf(fileTime string, now time.Time) string {
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", fileTime, time.Local); err == nil {
+		return rotationWeek(t)
+	}
+	return rotationWeek(now)
+}
+
+// isWeekFree 上游用 "1" 表示免费
+func isWeekFree(flag string) bool {
+	return flag == "1" || flag == "true"
+}
+
+// recordLOLRotation 记录本周的免费英雄，英雄列表每次重新拉取都会覆盖周免标记，所以不受版本判断的影响
+func recordLOLRotation(ctx *context.Context, heroList *dto.LOLHeroes) {
+	if heroList == nil {
+		return
+	}
+	mr := &model.HeroRotation{}
+	free := map[string][]string{
+		mr.TypeWeek():      make([]string, 0),
+		mr.TypeARAM():      make([]string, 0),
+		mr.TypePermanent(): make([]string, 0),
+	}
+	for _, hero := range heroList.Hero {
+		if isWeekFree(hero.IsWeekFree) {
+			free[mr.TypeWeek()] = append(free[mr.TypeWeek()], hero.HeroId)
+		}
+		if isWeekFree(hero.IsARAMWeekFree) {
+			free[mr.TypeARAM()] = append(free[mr.TypeARAM()], hero.HeroId)
+		}
+		if isWeekFree(hero.IsPermanentWeekFree) {
+			free[mr.TypePermanent()] = append(free[mr.TypePermanent()], hero.HeroId)
+		}
+	}
+	recordHeroRotation(ctx, common.PlatformForLOL, heroList.Version, heroList.FileTime, free)
+}
+
+func recordLOLMRotation(ctx *context.Context, heroList *dto.LOLMHeroes) {
+	if heroList == nil {
+		return
+	}
+	mr := &model.HeroRotation{}
+	free := map[string][]string{
+		mr.TypeWeek(): make([]string, 0),
+	}
+	for _, hero := range heroList.HeroList {
+		if isWeekFree(hero.IsWeekFree) {
+			free[mr.TypeWeek()] = append(free[mr.TypeWeek()], hero.HeroId)
+		}
+	}
+	recordHeroRotation(ctx, common.PlatformForLOLM, heroList.Version, heroList.FileTime, free)
+}
+
+// recordHeroRotation 按上游文件时间所在的周记录，同一周重复执行时按类型覆盖，没有免费英雄的类型视为上游缺失，保留原来的记录
+func recordHeroRotation(ctx *context.Context, platform int, version, fileTime string, free map[string][]string) {
+	week := rotationWeekOf(fileTime, time.Now())
+	hrd := dao.NewHeroRotationDAO()
+	for typ, ids := range free {
+		if len(ids) == 0 {
+			continue
+		}
+		rows := make([]*model.HeroRotation, 0, len(ids))
+		for _, id := range ids {
+			rows = append(rows, &model.HeroRotation{
+				HeroId:   id,
+				Type:     typ,
+				Week:     week,
+				Platform: platform,
+				Version:  version,
+				FileTime: fileTime,
+			})
+		}
+		err := hrd.DeleteAndInsert(map[string]interface{}{
+			"platform": platform,
+			"type":     typ,
+			"week":     week,
+		}, rows)
+		if err != nil {
+			log.Logger.Error(ctx, "Add HeroRotation "+err.Error()+",type:"+typ)
+			continue
+		}
+		log.Logger.Info(ctx, fmt.Sprintf("Add HeroRotation platform:%d type:%s week:%s heroes:%d", platform, typ, week, len(rows)))
+	}
+}
+
+func rotationHeroes(platform int, ids []string) []*dto.RotationHero {
+	heroes := heroBriefMap(platform, ids)
+	result := make([]*dto.RotationHero, 0, len(ids))
+	for _, id := range ids {
+		rh := &dto.RotationHero{HeroId: id}
+		if hero, ok := heroes[id]; ok {
+			rh.Name = heroDisplayName(platform, hero)
+			rh.Avatar = hero.Avatar
+		}
+		result = append(result, rh)
+	}
+	return result
+}
+
+// GetWeekFreeHeroes 最近一周的免费英雄，附带出装
+func GetWeekFreeHeroes(ctx *context.Context, platform int, typ string, tier int) (*dto.RespWeekFree, error) {
+	if typ == "" {
+		typ = new(model.HeroRotation).TypeWeek()
+	}
+	cond := map[string]interface{}{
+		"platform": platform,
+		"type":     typ,
+	}
+	hrd := dao.NewHeroRotationDAO()
+	week, err := hrd.LatestWeek(cond)
+	if err != nil {
+		return nil, err
+	}
+	if week == "" {
+		return nil, errors.New("no rotation recorded")
+	}
+	rows, err := hrd.Find(cond, week, week)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.HeroId)
+	}
+	resp := &dto.RespWeekFree{
+		Week:   week,
+		Type:   typ,
+		Heroes: make([]*dto.WeekFreeHero, 0, len(ids)),
+	}
+	// 大乱斗周免只推荐大乱斗可用的召唤师技能
+	mode := ""
+	if typ == new(model.HeroRotation).TypeARAM() {
+		mode = SkillModeARAM
+	}
+	for _, hero := range rotationHeroes(platform, ids) {
+		suit, err := GetHeroSuitForMode(ctx, hero.HeroId, tier, mode)
+		if err != nil {
+			log.Logger.Warn(ctx, "GetHeroSuit:", err, "heroId:", hero.HeroId)
+		}
+		resp.Heroes = append(resp.Heroes, &dto.WeekFreeHero{
+			RotationHero: *hero,
+			Suit:         &suit,
+		})
+	}
+	return resp, nil
+}
+
+// GetHeroLastFree 英雄最近一次免费的周和免费过的周数
+func GetHeroLastFree(ctx *context.Context, platform int, heroID string) (*dto.RespHeroLastFree, error) {
+	mr := &model.HeroRotation{}
+	types := []string{mr.TypeWeek()}
+	if platform == common.PlatformForLOL {
+		types = append(types, mr.TypeARAM(), mr.TypePermanent())
+	}
+
+	resp := &dto.RespHeroLastFree{
+		RotationHero: *rotationHeroes(platform, []string{heroID})[0],
+		Rotations:    make([]*dto.LastFree, 0, len(types)),
+	}
+	hrd := dao.NewHeroRotationDAO()
+	for _, typ := range types {
+		latest, err := hrd.LatestWeek(map[string]interface{}{
+			"platform": platform,
+			"type":     typ,
+		})
+		if err != nil {
+			return nil, err
+		}
+		weeks, err := hrd.FindWeeks(map[string]interface{}{
+			"heroId":   heroID,
+			"platform": platform,
+			"type":     typ,
+		})
+		if err != nil {
+			return nil, err
+		}
+		lf := &dto.LastFree{Type: typ, Times: len(weeks)}
+		if len(weeks) > 0 {
+			lf.LastWeek = weeks[0]
+			lf.IsFree = weeks[0] == latest
+		}
+		resp.Rotations = append(resp.Rotations, lf)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("last free hero:%s platform:%d", heroID, platform))
+	return resp, nil
+}
+
+// GetRotationCalendar 按周列出 [start, end] 内的免费英雄，默认最近12周
+func GetRotationCalendar(ctx *context.Context, platform int, typ, start, end string) (*dto.RespRotationCalendar, error) {
+	if typ == "" {
+		typ = new(model.HeroRotation).TypeWeek()
+	}
+	start, end = trendRange(start, end, DefaultRotationWeeks*7)
+	rows, err := dao.NewHeroRotationDAO().Find(map[string]interface{}{
+		"platform": platform,
+		"type":     typ,
+	}, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.HeroId)
+	}
+	heroes := make(map[string]*dto.RotationHero)
+	for _, hero := range rotationHeroes(platform, ids) {
+		heroes[hero.HeroId] = hero
+	}
+
+	resp := &dto.RespRotationCalendar{
+		Type:  typ,
+		Start: start,
+		End:   end,
+		Weeks: make([]*dto.RotationWeek, 0),
+	}
+	var cur *dto.RotationWeek
+	for _, row := range rows {
+		if cur == nil || cur.Week != row.Week {
+			cur = &dto.RotationWeek{Week: row.Week, Heroes: make([]*dto.RotationHero, 0)}
+			resp.Weeks = append(resp.Weeks, cur)
+		}
+		cur.Heroes = append(cur.Heroes, heroes[row.HeroId])
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("rotation calendar platform:%d type:%s %s~%s weeks:%d", platform, typ, start, end, len(resp.Weeks)))
+	return resp, nil
+}
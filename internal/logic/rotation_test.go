@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotationWeek(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		// 2023-10-02 是周一
+		{"2023-10-02 00:00:00", "2023-10-02"},
+		{"2023-10-04 12:00:00", "2023-10-02"},
+		{"2023-10-08 23:59:59", "2023-10-02"},
+		{"2023-10-09 00:00:01", "2023-10-09"},
+		// 跨月、跨年
+		{"2023-11-01 08:00:00", "2023-10-30"},
+		{"2024-01-01 08:00:00", "2024-01-01"},
+		{"2023-01-01 08:00:00", "2022-12-26"},
+	}
+	for _, c := range cases {
+		tm, err := time.ParseInLocation("2006-01-02 15:04:05", c.in, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rotationWeek(tm); got != c.want {
+			t.Errorf("rotationWeek(%s) = %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestRotationWeekOf(t *testing.T) {
+	// 2023-10-04 是周三
+	now := time.Date(2023, 10, 4, 12, 0, 0, 0, time.Local)
+	cases := []struct {
+		fileTime string
+		want     string
+	}{
+		// 周中拉取时上游还是上周的周免，记在上周，不会被本周的周免覆盖
+		{"2023-09-29 10:00:00", "2023-09-25"},
+		// 周中更换的周免记在本周
+		{"2023-10-03 10:00:00", "2023-10-02"},
+		// 文件时间缺失或无法解析时按当前时间
+		{"", "2023-10-02"},
+		{"20231003", "2023-10-02"},
+	}
+	for _, c := range cases {
+		if got := rotationWeekOf(c.fileTime, now); got != c.want {
+			t.Errorf("rotationWeekOf(%q) = %s, want %s", c.fileTime, got, c.want)
+		}
+	}
+}
+
+func TestIsWeekFree(t *testing.T) {
+	cases := []struct {
+		flag string
+		want bool
+	}{
+		{"1", true},
+		{"true", true},
+		{"0", false},
+		{"", false},
+		{"false", false},
+	}
+	for _, c := range cases {
+		if got := isWeekFree(c.flag); got != c.want {
+			t.Errorf("isWeekFree(%q) = %v, want %v", c.flag, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"regexp"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// 属性碎片的ID区间（LOL）
+const (
+	minRuneShardID = 5000
+	maxRuneShardID = 5999
+)
+
+var runeIDRegex = regexp.MustCompile(`\d{4,}`)
+
+// runePageKeys 上游记录中符文页字段可能的名称
+var runePageKeys = []string{"perkdetail", "perks", "perkids", "runes", "runeids"}
+
+// parseRuneIDs 从 "8010&9111&9104..." 或 "[8010,9111]" 之类的字符串中提取符文ID
+func parseRuneIDs(v interface{}) []string {
+	switch data := v.(type) {
+	case string:
+		return runeIDRegex.FindAllString(data, -1)
+	case []interface{}:
+		result := make([]string, 0, len(data))
+		for _, item := range data {
+			if id := cast.ToString(item); id != "" {
+				result = append(result, id)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// runePageIDs 完整符文页至少包含基石、主系三个、副系两个
+func runePageIDs(m map[string]interface{}) []string {
+	for _, key := range runePageKeys {
+		if ids := parseRuneIDs(m[key]); len(ids) >= 6 {
+			return ids
+		}
+	}
+	return nil
+}
+
+// loadRuneBriefs 最新版本的符文简要信息，key为符文ID
+func loadRuneBriefs(platform int) (map[string]*dto.RuneBrief, error) {
+	result := make(map[string]*dto.RuneBrief)
+	if platform == common.PlatformForLOL {
+		rd := dao.NewLOLRuneDAO()
+		v, err := rd.GetLOLRuneMaxVersion()
+		if err != nil || v == nil {
+			return result, err
+		}
+		runes, err := rd.GetLOLRune(v.Version)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runes {
+			result[r.RuneID] = &dto.RuneBrief{
+				ID:        r.RuneID,
+				Name:      r.Name,
+				Icon:      r.Icon,
+				StyleName: r.StyleName,
+				SlotLabel: r.SlotLabel,
+			}
+		}
+	} else {
+		rd := dao.NewLOLMRuneDAO()
+		v, err := rd.GetLOLMRuneMaxVersion()
+		if err != nil || v == nil {
+			return result, err
+		}
+		runes, err := rd.GetLOLMRune(v.Version)
+		if err != nil {
+			return nil, err
+		}
+		// LOLM 没有 styleName，和符文树一样按 type、primarySlotIndex 分组
+		for _, r := range runes {
+			style := r.StyleName
+			if style == "" {
+				style = r.Type
+			}
+			result[r.RuneId] = &dto.RuneBrief{
+				ID:        r.RuneId,
+				Name:      r.Name,
+				Icon:      r.IconPath,
+				StyleName: style,
+				SlotLabel: r.PrimarySlotIndex,
+			}
+		}
+	}
+	return result, nil
+}
+
+// isRuneShard LOL的属性碎片，LOLM没有属性碎片
+func isRuneShard(id string, brief *dto.RuneBrief) bool {
+	n := cast.ToInt(id)
+	if n >= minRuneShardID && n <= maxRuneShardID {
+		return true
+	}
+	return brief != nil && brief.StyleName == ""
+}
+
+// classifyRunePage 把一组符文ID拆分成主系、基石、副系和属性碎片
+// 符文数最多的系为主系，主系中槽位为基石的符文（没有时取第一个）为基石符文
+func classifyRunePage(ids []string, briefs map[string]*dto.RuneBrief) *model.HeroesRunePage {
+	styleCount := make(map[string]int)
+	styleOrder := make([]string, 0, 2)
+	shards := make([]string, 0, 3)
+	for _, id := range ids {
+		brief := briefs[id]
+		if isRuneShard(id, brief) {
+			shards = append(shards, id)
+			continue
+		}
+		if brief == nil {
+			continue
+		}
+		if styleCount[brief.StyleName] == 0 {
+			styleOrder = append(styleOrder, brief.StyleName)
+		}
+		styleCount[brief.StyleName]++
+	}
+	if len(styleOrder) == 0 {
+		return nil
+	}
+
+	primary := styleOrder[0]
+	for _, style := range styleOrder {
+		if styleCount[style] > styleCount[primary] {
+			primary = style
+		}
+	}
+	var secondary string
+	for _, style := range styleOrder {
+		if style != primary {
+			secondary = style
+			break
+		}
+	}
+
+	var keystone string
+	primaryRunes := make([]string, 0, 4)
+	secondaryRunes := make([]string, 0, 2)
+	for _, id := range ids {
+		brief := briefs[id]
+		if brief == nil || isRuneShard(id, brief) {
+			continue
+		}
+		switch brief.StyleName {
+		case primary:
+			if keystone == "" && strings.Contains(brief.SlotLabel, "基石") {
+				keystone = id
+				continue
+			}
+			primaryRunes = append(primaryRunes, id)
+		case secondary:
+			secondaryRunes = append(secondaryRunes, id)
+		}
+	}
+	if keystone == "" && len(primaryRunes) > 0 {
+		keystone = primaryRunes[0]
+		primaryRunes = primaryRunes[1:]
+	}
+
+	return &model.HeroesRunePage{
+		PrimaryStyle:   primary,
+		Keystone:       keystone,
+		PrimaryRunes:   strings.Join(primaryRunes, ","),
+		SecondaryStyle: secondary,
+		SecondaryRunes: strings.Join(secondaryRunes, ","),
+		Shards:         strings.Join(shards, ","),
+		Runeids:        strings.Join(ids, ","),
+	}
+}
+
+// classifyLOLMRunePage LOLM的符文页没有主副系和属性碎片：槽位最小的符文为基石，基石所在的系记为主系，其余符文按顺序记录
+func classifyLOLMRunePage(ids []string, briefs map[string]*dto.RuneBrief) *model.HeroesRunePage {
+	var keystone *dto.RuneBrief
+	for _, id := range ids {
+		brief := briefs[id]
+		if brief == nil {
+			continue
+		}
+		if keystone == nil || cast.ToInt(brief.SlotLabel) < cast.ToInt(keystone.SlotLabel) {
+			keystone = brief
+		}
+	}
+	if keystone == nil {
+		return nil
+	}
+
+	runes := make([]string, 0, MaxLOLMRunes-1)
+	for _, id := range ids {
+		if briefs[id] != nil && id != keystone.ID {
+			runes = append(runes, id)
+		}
+	}
+	return &model.HeroesRunePage{
+		PrimaryStyle: keystone.StyleName,
+		Keystone:     keystone.ID,
+		PrimaryRunes: strings.Join(runes, ","),
+		Runeids:      strings.Join(ids, ","),
+	}
+}
+
+// runePagesFromLOL 解析LOL的符文页：每个位置的 perkdetail 和 Styledetails
+func runePagesFromLOL(fightData *dto.ChampionFightData, styledetails string, briefs map[string]*dto.RuneBrief) []*model.HeroesRunePage {
+	match := func(m map[string]interface{}) bool {
+		return runePageIDs(m) != nil
+	}
+	records := make([]jdataRecord, 0)
+	for pos, lane := range fightData.List.ChampionLane {
+		for _, r := range parseJData(lane.Perkdetail, match) {
+			if r.Lane == "" {
+				r.Lane = pos
+			}
+			records = append(records, r)
+		}
+	}
+	records = append(records, parseJData(styledetails, match)...)
+
+	result := make([]*model.HeroesRunePage, 0, len(records))
+	exists := make(map[string]bool)
+	for _, r := range records {
+		page := classifyRunePage(runePageIDs(r.Data), briefs)
+		if page == nil {
+			continue
+		}
+		page.Pos = r.Lane
+		if page.Pos == "" {
+			page.Pos = common.PositionNameEN[0]
+		}
+		// 两个来源可能有重复的符文页
+		if exists[page.Pos+page.Runeids] {
+			continue
+		}
+		exists[page.Pos+page.Runeids] = true
+		page.Igamecnt = cast.ToInt32(r.Data["igamecnt"])
+		page.Wincnt = cast.ToInt32(r.Data["wincnt"])
+		page.Winrate = cast.ToInt32(r.Data["winrate"])
+		page.Showrate = cast.ToInt32(r.Data["showrate"])
+		if page.Winrate == 0 && page.Igamecnt > 0 {
+			page.Winrate = page.Wincnt * 10000 / page.Igamecnt
+		}
+		result = append(result, page)
+	}
+	return result
+}
+
+// runePagesFromLOLM 解析LOLM每套出装推荐里的符文，LOLM没有场次和胜率数据
+func runePagesFromLOLM(equipTechs map[string]*dto.EquipTech, briefs map[string]*dto.RuneBrief) []*model.HeroesRunePage {
+	result := make([]*model.HeroesRunePage, 0, len(equipTechs))
+	for _, et := range equipTechs {
+		ids := make([]string, 0)
+		for _, nl := range et.Data.RuneInfo.NewList {
+			for _, item := range nl.Items {
+				ids = append(ids, item.Id)
+			}
+		}
+		page := classifyLOLMRunePage(ids, briefs)
+		if page == nil {
+			continue
+		}
+		page.Pos = et.Data.TopInfo.Title
+		result = append(result, page)
+	}
+	return result
+}
+
+// updateHeroesRunePage 记录英雄的符文页推荐，LOL按版本保留，LOLM只保留最新一份
+func updateHeroesRunePage(ctx *context.Context, platform int, heroId, version, fileTime string, pages []*model.HeroesRunePage) error {
+	if len(pages) == 0 {
+		log.Logger.Warn(ctx, "rune page is nil", "heroId:", heroId)
+		return nil
+	}
+	for _, p := range pages {
+		p.HeroId = heroId
+		p.Platform = platform
+		p.Version = version
+		p.FileTime = fileTime
+	}
+
+	delCond := map[string]interface{}{
+		"heroId":   heroId,
+		"platform": platform,
+	}
+	if platform == common.PlatformForLOL {
+		delCond["version"] = version
+	}
+	err := dao.NewHeroesRunePageDAO().DeleteAndInsert(delCond, pages)
+	if err != nil {
+		return errors.New("Add HeroesRunePage " + err.Error() + ",heroId:" + heroId)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Add HeroesRunePage heroId:%s rows:%d", heroId, len(pages)))
+	return nil
+}
+
+func runeBriefList(ids string, briefs map[string]*dto.RuneBrief) []*dto.RuneBrief {
+	result := make([]*dto.RuneBrief, 0)
+	for _, id := range splitTags(ids) {
+		if brief, ok := briefs[id]; ok {
+			result = append(result, brief)
+		} else {
+			result = append(result, &dto.RuneBrief{ID: id})
+		}
+	}
+	return result
+}
+
+// GetHeroRunePages 英雄的推荐符文页，version为空时取最新版本
+func GetHeroRunePages(ctx *context.Context, platform int, heroID, pos, version string) ([]*dto.RunePage, error) {
+	cond := map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+	}
+	if pos != "" {
+		cond["pos"] = pos
+	}
+	if version != "" {
+		cond["version"] = version
+	}
+	rows, err := dao.NewHeroesRunePageDAO().Find(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	briefs, err := loadRuneBriefs(platform)
+	if err != nil {
+		log.Logger.Warn(ctx, "loadRuneBriefs:", err)
+	}
+
+	result := make([]*dto.RunePage, 0, len(rows))
+	for _, row := range rows {
+		// 按版本倒序，只取最新版本
+		if row.Version != rows[0].Version {
+			break
+		}
+		page := &dto.RunePage{
+			Pos: row.Pos,
+			Primary: dto.RuneStyle{
+				Name:  row.PrimaryStyle,
+				Runes: runeBriefList(row.PrimaryRunes, briefs),
+			},
+			Secondary: dto.RuneStyle{
+				Name:  row.SecondaryStyle,
+				Runes: runeBriefList(row.SecondaryRunes, briefs),
+			},
+			Shards:   runeBriefList(row.Shards, briefs),
+			Igamecnt: row.Igamecnt,
+			Winrate:  row.Winrate,
+			Showrate: row.Showrate,
+			Version:  row.Version,
+			Platform: row.Platform,
+		}
+		if keystone := runeBriefList(row.Keystone, briefs); len(keystone) > 0 {
+			page.Keystone = keystone[0]
+		}
+		result = append(result, page)
+	}
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/dto"
+)
+
+func TestParseRuneIDs(t *testing.T) {
+	cases := []struct {
+		in   interface{}
+		want []string
+	}{
+		{"8010&9111&9104", []string{"8010", "9111", "9104"}},
+		{"[8010,9111]", []string{"8010", "9111"}},
+		{[]interface{}{8010.0, "9111", ""}, []string{"8010", "9111"}},
+		{"12,34", []string{}},
+		{nil, nil},
+	}
+	for _, c := range cases {
+		got := parseRuneIDs(c.in)
+		if len(got) == 0 && len(c.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseRuneIDs(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestRunePageIDs(t *testing.T) {
+	full := "8010&9111&9104&8299&8345&8347&5005&5008&5002"
+	if got := runePageIDs(map[string]interface{}{"perks": "8010&9111", "runes": full}); len(got) != 9 {
+		t.Errorf("runePageIDs should skip short fields, got %v", got)
+	}
+	if got := runePageIDs(map[string]interface{}{"perks": "8010&9111"}); got != nil {
+		t.Errorf("runePageIDs = %v, want nil", got)
+	}
+}
+
+// testRuneBriefs 精密主系、启迪副系
+func testRuneBriefs() map[string]*dto.RuneBrief {
+	brief := func(id, style, slot string) *dto.RuneBrief {
+		return &dto.RuneBrief{ID: id, StyleName: style, SlotLabel: slot}
+	}
+	return map[string]*dto.RuneBrief{
+		"8010": brief("8010", "精密", "基石"),
+		"8008": brief("8008", "精密", "基石"),
+		"9111": brief("9111", "精密", "英勇"),
+		"9104": brief("9104", "精密", "传说"),
+		"8299": brief("8299", "精密", "战斗"),
+		"8345": brief("8345", "启迪", "独创"),
+		"8347": brief("8347", "启迪", "超越"),
+		"5005": brief("5005", "", ""),
+	}
+}
+
+func TestClassifyRunePage(t *testing.T) {
+	briefs := testRuneBriefs()
+	page := classifyRunePage([]string{"8345", "8010", "9111", "9104", "8299", "8347", "5005", "5008"}, briefs)
+	if page == nil {
+		t.Fatal("page is nil")
+	}
+	got := []string{page.PrimaryStyle, page.Keystone, page.PrimaryRunes, page.SecondaryStyle, page.SecondaryRunes, page.Shards}
+	want := []string{"精密", "8010", "9111,9104,8299", "启迪", "8345,8347", "5005,5008"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("classifyRunePage = %v, want %v", got, want)
+	}
+
+	// 没有基石槽位信息时取主系的第一个
+	page = classifyRunePage([]string{"9111", "9104"}, briefs)
+	if page.Keystone != "9111" || page.PrimaryRunes != "9104" {
+		t.Errorf("fallback keystone = %+v", page)
+	}
+
+	if page = classifyRunePage([]string{"5005", "1234"}, briefs); page != nil {
+		t.Errorf("page without styles should be nil, got %+v", page)
+	}
+}
+
+func TestIsRuneShard(t *testing.T) {
+	cases := []struct {
+		id    string
+		brief *dto.RuneBrief
+		want  bool
+	}{
+		{"5008", nil, true},
+		{"8010", &dto.RuneBrief{StyleName: "精密"}, false},
+		{"8010", nil, false},
+		{"20001", &dto.RuneBrief{}, true},
+	}
+	for _, c := range cases {
+		if got := isRuneShard(c.id, c.brief); got != c.want {
+			t.Errorf("isRuneShard(%s) = %v, want %v", c.id, got, c.want)
+		}
+	}
+}
+
+// testLOLMRuneBriefs LOLM的符文按 type 分系，槽位1为基石，没有属性碎片
+func testLOLMRuneBriefs() map[string]*dto.RuneBrief {
+	brief := func(id, style, slot string) *dto.RuneBrief {
+		return &dto.RuneBrief{ID: id, StyleName: style, SlotLabel: slot}
+	}
+	return map[string]*dto.RuneBrief{
+		"5001": brief("5001", "主宰", "1"),
+		"5101": brief("5101", "主宰", "2"),
+		"5201": brief("5201", "坚决", "3"),
+		"5301": brief("5301", "启迪", "4"),
+	}
+}
+
+func TestClassifyLOLMRunePage(t *testing.T) {
+	briefs := testLOLMRuneBriefs()
+	cases := []struct {
+		name string
+		ids  []string
+		want []string // 主系、基石、其余符文
+	}{
+		{"keystone first", []string{"5001", "5101", "5201", "5301"}, []string{"主宰", "5001", "5101,5201,5301"}},
+		// ID在LOL属性碎片区间内也不当作碎片
+		{"keystone last", []string{"5301", "5201", "5101", "5001"}, []string{"主宰", "5001", "5301,5201,5101"}},
+		{"without keystone slot", []string{"5201", "5301"}, []string{"坚决", "5201", "5301"}},
+		{"unknown ignored", []string{"9999", "5001"}, []string{"主宰", "5001", ""}},
+	}
+	for _, c := range cases {
+		page := classifyLOLMRunePage(c.ids, briefs)
+		if page == nil {
+			t.Errorf("%s: page is nil", c.name)
+			continue
+		}
+		got := []string{page.PrimaryStyle, page.Keystone, page.PrimaryRunes}
+		if !reflect.DeepEqual(got, c.want) || page.SecondaryStyle != "" || page.Shards != "" {
+			t.Errorf("%s: classifyLOLMRunePage = %+v, want %v", c.name, page, c.want)
+		}
+	}
+	if page := classifyLOLMRunePage([]string{"9999"}, briefs); page != nil {
+		t.Errorf("page without known runes should be nil, got %+v", page)
+	}
+}
+
+func TestRunePagesFromLOLM(t *testing.T) {
+	items := func(ids ...string) []dto.EquipTechRuneInfoNewListItems {
+		result := make([]dto.EquipTechRuneInfoNewListItems, 0, len(ids))
+		for _, id := range ids {
+			result = append(result, dto.EquipTechRuneInfoNewListItems{Id: id})
+		}
+		return result
+	}
+	et := &dto.EquipTech{}
+	et.Data.TopInfo.Title = "打野"
+	et.Data.RuneInfo.NewList = []dto.EquipTechRuneInfoNewList{
+		{Title: "基石", Items: items("5001")},
+		{Title: "符文", Items: items("5101", "5201", "5301")},
+	}
+	pages := runePagesFromLOLM(map[string]*dto.EquipTech{"1": et}, testLOLMRuneBriefs())
+	if len(pages) != 1 {
+		t.Fatalf("runePagesFromLOLM = %d pages, want 1", len(pages))
+	}
+	if p := pages[0]; p.Pos != "打野" || p.Keystone != "5001" || p.Runeids != "5001,5101,5201,5301" {
+		t.Errorf("runePagesFromLOLM = %+v", p)
+	}
+}
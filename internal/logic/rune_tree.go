@@ -0,0 +1,402 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// 符文页校验规则
+const (
+	RuneRuleUnknown           = "unknownRune"
+	RuneRuleDuplicate         = "duplicateRune"
+	RuneRuleSameStyle         = "sameStyle"
+	RuneRuleStyleMismatch     = "styleMismatch"
+	RuneRuleSameSlot          = "sameSlot"
+	RuneRuleKeystoneSecondary = "keystoneInSecondary"
+	RuneRuleKeystoneCount     = "keystoneCount"
+	RuneRuleRuneCount         = "runeCount"
+	RuneRuleShardCount        = "shardCount"
+)
+
+// LOL符文页中各部分的数量上限
+const (
+	MaxPrimaryRunes   = 3
+	MaxSecondaryRunes = 2
+	MaxRuneShards     = 3
+)
+
+// MaxLOLMRunes LOLM符文页没有主副系，一个基石加上其余每个槽位一个符文
+const MaxLOLMRunes = 4
+
+// runeSlot 符文在符文树中的位置
+type runeSlot struct {
+	style    string
+	slot     int
+	keystone bool
+	shard    bool
+}
+
+// lolRuneSlotOrder LOL各系基石之后的槽位顺序，上游的符文列表是map，没有槽位顺序
+var lolRuneSlotOrder = map[string][]string{
+	"精密": {"英勇", "传说", "战斗"},
+	"主宰": {"恶意", "追踪", "猎人"},
+	"巫术": {"神器", "卓越", "力量"},
+	"坚决": {"力量", "抗性", "生命力"},
+	"启迪": {"装置", "明日", "超越"},
+}
+
+// lolRuneSlotRank 槽位的顺序，基石为0，其余按 lolRuneSlotOrder，不在表中的排在最后
+func lolRuneSlotRank(style, label string) int {
+	if strings.Contains(label, "基石") {
+		return 0
+	}
+	order := lolRuneSlotOrder[style]
+	for i, l := range order {
+		if strings.Contains(label, l) {
+			return i + 1
+		}
+	}
+	return len(order) + 1
+}
+
+// loadRuneTree 指定版本的符文树，版本为空时取最新版本
+// LOL按 styleName、slotLabel 分组，槽位按 lolRuneSlotOrder 排序；LOLM按 type、primarySlotIndex 分组
+func loadRuneTree(platform int, version string) (*dto.RuneTree, error) {
+	tree := &dto.RuneTree{
+		Platform: platform,
+		Styles:   make([]*dto.RuneTreeStyle, 0),
+		Shards:   make([]*dto.RuneTreeSlot, 0),
+	}
+	styles := make(map[string]*dto.RuneTreeStyle)
+	slots := make(map[string]*dto.RuneTreeSlot)
+	addRune := func(style, slotKey, label string, brief *dto.RuneBrief) {
+		s, ok := styles[style]
+		if !ok {
+			s = &dto.RuneTreeStyle{Name: style, Slots: make([]*dto.RuneTreeSlot, 0)}
+			styles[style] = s
+			tree.Styles = append(tree.Styles, s)
+		}
+		slot, ok := slots[style+"_"+slotKey]
+		if !ok {
+			slot = &dto.RuneTreeSlot{Label: label, Runes: make([]*dto.RuneBrief, 0)}
+			slots[style+"_"+slotKey] = slot
+			s.Slots = append(s.Slots, slot)
+		}
+		slot.Runes = append(slot.Runes, brief)
+	}
+	shardSlots := make(map[string]*dto.RuneTreeSlot)
+	addShard := func(label string, brief *dto.RuneBrief) {
+		slot, ok := shardSlots[label]
+		if !ok {
+			slot = &dto.RuneTreeSlot{Label: label, Runes: make([]*dto.RuneBrief, 0)}
+			shardSlots[label] = slot
+			tree.Shards = append(tree.Shards, slot)
+		}
+		slot.Runes = append(slot.Runes, brief)
+	}
+
+	// LOLM 槽位内的排序
+	slotOrder := make(map[string]int)
+	if platform == common.PlatformForLOL {
+		rd := dao.NewLOLRuneDAO()
+		if version == "" {
+			v, err := rd.GetLOLRuneMaxVersion()
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				return nil, errors.New("rune data not found")
+			}
+			version = v.Version
+		}
+		runes, err := rd.GetLOLRune(version)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runes {
+			brief := &dto.RuneBrief{
+				ID:        r.RuneID,
+				Name:      r.Name,
+				Icon:      r.Icon,
+				StyleName: r.StyleName,
+				SlotLabel: r.SlotLabel,
+			}
+			if isRuneShard(r.RuneID, brief) {
+				addShard(r.SlotLabel, brief)
+				continue
+			}
+			addRune(r.StyleName, r.SlotLabel, r.SlotLabel, brief)
+		}
+		for _, s := range tree.Styles {
+			sort.SliceStable(s.Slots, func(i, j int) bool {
+				ri, rj := lolRuneSlotRank(s.Name, s.Slots[i].Label), lolRuneSlotRank(s.Name, s.Slots[j].Label)
+				if ri != rj {
+					return ri < rj
+				}
+				return s.Slots[i].Label < s.Slots[j].Label
+			})
+		}
+	} else {
+		rd := dao.NewLOLMRuneDAO()
+		if version == "" {
+			v, err := rd.GetLOLMRuneMaxVersion()
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				return nil, errors.New("rune data not found")
+			}
+			version = v.Version
+		}
+		runes, err := rd.GetLOLMRune(version)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runes {
+			style := r.StyleName
+			if style == "" {
+				style = r.Type
+			}
+			slotOrder[r.RuneId] = cast.ToInt(r.PrimarySlotSortOrder)
+			addRune(style, r.PrimarySlotIndex, r.PrimarySlotIndex, &dto.RuneBrief{
+				ID:        r.RuneId,
+				Name:      r.Name,
+				Icon:      r.IconPath,
+				StyleName: style,
+				SlotLabel: r.PrimarySlotIndex,
+			})
+		}
+		for _, s := range tree.Styles {
+			sort.SliceStable(s.Slots, func(i, j int) bool {
+				return cast.ToInt(s.Slots[i].Label) < cast.ToInt(s.Slots[j].Label)
+			})
+		}
+	}
+	tree.Version = version
+
+	sort.SliceStable(tree.Styles, func(i, j int) bool {
+		return tree.Styles[i].Name < tree.Styles[j].Name
+	})
+	for _, s := range tree.Styles {
+		for i, slot := range s.Slots {
+			slot.Index = i
+			slot.Keystone = i == 0
+			sort.SliceStable(slot.Runes, func(a, b int) bool {
+				if slotOrder[slot.Runes[a].ID] != slotOrder[slot.Runes[b].ID] {
+					return slotOrder[slot.Runes[a].ID] < slotOrder[slot.Runes[b].ID]
+				}
+				return cast.ToInt(slot.Runes[a].ID) < cast.ToInt(slot.Runes[b].ID)
+			})
+		}
+	}
+	for i, slot := range tree.Shards {
+		slot.Index = i
+	}
+	return tree, nil
+}
+
+// GetRuneTree 符文树
+func GetRuneTree(ctx *context.Context, platform int, version string) (*dto.RuneTree, error) {
+	tree, err := loadRuneTree(platform, version)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("rune tree platform:%d version:%s styles:%d", platform, tree.Version, len(tree.Styles)))
+	return tree, nil
+}
+
+func runeSlotIndex(tree *dto.RuneTree) map[string]*runeSlot {
+	result := make(map[string]*runeSlot)
+	for _, s := range tree.Styles {
+		for _, slot := range s.Slots {
+			for _, r := range slot.Runes {
+				result[r.ID] = &runeSlot{style: s.Name, slot: slot.Index, keystone: slot.Keystone}
+			}
+		}
+	}
+	for _, slot := range tree.Shards {
+		for _, r := range slot.Runes {
+			result[r.ID] = &runeSlot{slot: slot.Index, shard: true}
+		}
+	}
+	return result
+}
+
+type RunePageCheckParams struct {
+	Platform  int
+	Version   string
+	Primary   string // 主系，为空时取基石所在的系
+	Secondary string // 副系，为空时取主系以外的系
+	Runes     []string
+}
+
+// CheckRunePage 校验符文页是否合法：同一槽位只能选一个、只能有一个基石等，LOL还要校验主副系
+func CheckRunePage(ctx *context.Context, p *RunePageCheckParams) (*dto.RespRunePageCheck, error) {
+	tree, err := loadRuneTree(p.Platform, p.Version)
+	if err != nil {
+		return nil, err
+	}
+	resp := checkRunePage(tree, p)
+	log.Logger.Info(ctx, fmt.Sprintf("rune page check platform:%d version:%s runes:%v violations:%d", p.Platform, tree.Version, p.Runes, len(resp.Violations)))
+	return resp, nil
+}
+
+func checkRunePage(tree *dto.RuneTree, p *RunePageCheckParams) *dto.RespRunePageCheck {
+	index := runeSlotIndex(tree)
+
+	resp := &dto.RespRunePageCheck{
+		Version:    tree.Version,
+		Primary:    p.Primary,
+		Secondary:  p.Secondary,
+		Violations: make([]*dto.RuneViolation, 0),
+	}
+	addViolation := func(rule, msg string, runes []string) {
+		resp.Violations = append(resp.Violations, &dto.RuneViolation{Rule: rule, Message: msg, Runes: runes})
+	}
+
+	runes := make([]string, 0, len(p.Runes))
+	shards := make([]string, 0, MaxRuneShards)
+	unknown, dup := make([]string, 0), make([]string, 0)
+	seen := make(map[string]bool)
+	styleOrder := make([]string, 0, 2)
+	styleCount := make(map[string]int)
+	for _, id := range p.Runes {
+		rs, ok := index[id]
+		if !ok {
+			unknown = append(unknown, id)
+			continue
+		}
+		if rs.shard {
+			shards = append(shards, id)
+			continue
+		}
+		if seen[id] {
+			dup = append(dup, id)
+			continue
+		}
+		seen[id] = true
+		runes = append(runes, id)
+		if styleCount[rs.style] == 0 {
+			styleOrder = append(styleOrder, rs.style)
+		}
+		styleCount[rs.style]++
+		if resp.Primary == "" && rs.keystone {
+			resp.Primary = rs.style
+		}
+	}
+	if len(unknown) > 0 {
+		addViolation(RuneRuleUnknown, "符文不存在", unknown)
+	}
+	if len(dup) > 0 {
+		addViolation(RuneRuleDuplicate, "同一个符文不能重复选择", dup)
+	}
+
+	// LOLM 没有主副系，基石可以来自任意一个系，只校验槽位和数量
+	if tree.Platform != common.PlatformForLOL {
+		keystones := make([]string, 0)
+		for _, id := range runes {
+			if index[id].keystone {
+				keystones = append(keystones, id)
+			}
+		}
+		checkRuneSlots(index, runes, addViolation)
+		if len(keystones) > 1 || (len(runes) > 0 && len(keystones) == 0) {
+			addViolation(RuneRuleKeystoneCount, "需要选择一个基石符文", keystones)
+		}
+		if len(runes) > MaxLOLMRunes {
+			addViolation(RuneRuleRuneCount, fmt.Sprintf("最多选择%d个符文", MaxLOLMRunes), runes)
+		}
+		resp.Valid = len(resp.Violations) == 0
+		return resp
+	}
+
+	if resp.Primary == "" && len(styleOrder) > 0 {
+		resp.Primary = styleOrder[0]
+	}
+	if resp.Secondary == "" {
+		for _, style := range styleOrder {
+			if style != resp.Primary {
+				resp.Secondary = style
+				break
+			}
+		}
+	}
+	if resp.Primary != "" && resp.Primary == resp.Secondary {
+		addViolation(RuneRuleSameStyle, fmt.Sprintf("副系不能和主系[%s]相同", resp.Primary), nil)
+	}
+
+	mismatch := make([]string, 0)
+	keystones, secondaryKeystones := make([]string, 0), make([]string, 0)
+	primaryRunes, secondaryRunes := make([]string, 0), make([]string, 0)
+	matched := make([]string, 0, len(runes))
+	for _, id := range runes {
+		rs := index[id]
+		switch rs.style {
+		case resp.Primary:
+			if rs.keystone {
+				keystones = append(keystones, id)
+			} else {
+				primaryRunes = append(primaryRunes, id)
+			}
+		case resp.Secondary:
+			if rs.keystone {
+				secondaryKeystones = append(secondaryKeystones, id)
+			} else {
+				secondaryRunes = append(secondaryRunes, id)
+			}
+		default:
+			mismatch = append(mismatch, id)
+			continue
+		}
+		matched = append(matched, id)
+	}
+	if len(mismatch) > 0 {
+		addViolation(RuneRuleStyleMismatch, "符文不属于主系或副系", mismatch)
+	}
+	if len(secondaryKeystones) > 0 {
+		addViolation(RuneRuleKeystoneSecondary, "副系不能选择基石符文", secondaryKeystones)
+	}
+	checkRuneSlots(index, matched, addViolation)
+	if len(keystones) > 1 || (len(runes) > 0 && len(keystones) == 0) {
+		addViolation(RuneRuleKeystoneCount, "主系需要选择一个基石符文", keystones)
+	}
+	if len(primaryRunes) > MaxPrimaryRunes {
+		addViolation(RuneRuleRuneCount, fmt.Sprintf("主系最多选择%d个符文", MaxPrimaryRunes), primaryRunes)
+	}
+	if len(secondaryRunes) > MaxSecondaryRunes {
+		addViolation(RuneRuleRuneCount, fmt.Sprintf("副系最多选择%d个符文", MaxSecondaryRunes), secondaryRunes)
+	}
+	if len(shards) > MaxRuneShards {
+		addViolation(RuneRuleShardCount, fmt.Sprintf("最多选择%d个属性碎片", MaxRuneShards), shards)
+	}
+
+	resp.Valid = len(resp.Violations) == 0
+	return resp
+}
+
+// checkRuneSlots 同一槽位只能选一个符文，基石槽位的重复由 keystoneCount 报告
+func checkRuneSlots(index map[string]*runeSlot, runes []string, addViolation func(rule, msg string, runes []string)) {
+	slotRunes := make(map[string][]string)
+	slotOrder := make([]string, 0)
+	for _, id := range runes {
+		rs := index[id]
+		key := fmt.Sprintf("%s_%d", rs.style, rs.slot)
+		if len(slotRunes[key]) == 0 {
+			slotOrder = append(slotOrder, key)
+		}
+		slotRunes[key] = append(slotRunes[key], id)
+	}
+	for _, key := range slotOrder {
+		if len(slotRunes[key]) > 1 && !index[slotRunes[key][0]].keystone {
+			addViolation(RuneRuleSameSlot, "同一槽位只能选择一个符文", slotRunes[key])
+		}
+	}
+}
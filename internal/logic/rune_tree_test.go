@@ -0,0 +1,224 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+)
+
+func TestLOLRuneSlotRank(t *testing.T) {
+	cases := []struct {
+		style, label string
+		want         int
+	}{
+		{"精密", "基石", 0},
+		{"精密", "英勇", 1},
+		{"精密", "传说", 2},
+		{"精密", "战斗", 3},
+		// 同名的槽位在不同的系中顺序不同
+		{"巫术", "力量", 3},
+		{"坚决", "力量", 1},
+		{"精密", "未知", 4},
+		{"未知", "英勇", 1},
+	}
+	for _, c := range cases {
+		if got := lolRuneSlotRank(c.style, c.label); got != c.want {
+			t.Errorf("lolRuneSlotRank(%s, %s) = %d, want %d", c.style, c.label, got, c.want)
+		}
+	}
+}
+
+func newRuneSlot(index int, ids ...string) *dto.RuneTreeSlot {
+	slot := &dto.RuneTreeSlot{Index: index, Keystone: index == 0, Runes: make([]*dto.RuneBrief, 0)}
+	for _, id := range ids {
+		slot.Runes = append(slot.Runes, &dto.RuneBrief{ID: id})
+	}
+	return slot
+}
+
+// testLOLRuneTree 精密、启迪两个系，各有基石和三个槽位，以及三行属性碎片
+func testLOLRuneTree() *dto.RuneTree {
+	return &dto.RuneTree{
+		Platform: common.PlatformForLOL,
+		Version:  "13.20",
+		Styles: []*dto.RuneTreeStyle{
+			{Name: "精密", Slots: []*dto.RuneTreeSlot{
+				newRuneSlot(0, "8005", "8008", "8010"),
+				newRuneSlot(1, "9101", "9111"),
+				newRuneSlot(2, "9104", "9105"),
+				newRuneSlot(3, "8014", "8299"),
+			}},
+			{Name: "启迪", Slots: []*dto.RuneTreeSlot{
+				newRuneSlot(0, "8351", "8360"),
+				newRuneSlot(1, "8306", "8304"),
+				newRuneSlot(2, "8345", "8313"),
+				newRuneSlot(3, "8347", "8410"),
+			}},
+		},
+		Shards: []*dto.RuneTreeSlot{
+			newRuneSlot(0, "5005", "5008"),
+			newRuneSlot(1, "5002", "5003"),
+			newRuneSlot(2, "5001"),
+		},
+	}
+}
+
+// testLOLMRuneTree LOLM的基石在单独的系中，其余三个系各有一个槽位
+func testLOLMRuneTree() *dto.RuneTree {
+	return &dto.RuneTree{
+		Platform: common.PlatformForLOLM,
+		Version:  "4.4",
+		Styles: []*dto.RuneTreeStyle{
+			{Name: "基石", Slots: []*dto.RuneTreeSlot{newRuneSlot(0, "1", "2")}},
+			{Name: "主宰", Slots: []*dto.RuneTreeSlot{{Index: 1, Runes: []*dto.RuneBrief{{ID: "11"}, {ID: "12"}}}}},
+			{Name: "坚决", Slots: []*dto.RuneTreeSlot{{Index: 1, Runes: []*dto.RuneBrief{{ID: "21"}, {ID: "22"}}}}},
+			{Name: "启迪", Slots: []*dto.RuneTreeSlot{{Index: 1, Runes: []*dto.RuneBrief{{ID: "31"}, {ID: "32"}}}}},
+		},
+		Shards: make([]*dto.RuneTreeSlot, 0),
+	}
+}
+
+func TestRuneSlotIndex(t *testing.T) {
+	index := runeSlotIndex(testLOLRuneTree())
+	cases := []struct {
+		id   string
+		want runeSlot
+	}{
+		{"8008", runeSlot{style: "精密", slot: 0, keystone: true}},
+		{"9105", runeSlot{style: "精密", slot: 2}},
+		{"8347", runeSlot{style: "启迪", slot: 3}},
+		{"5002", runeSlot{slot: 1, shard: true}},
+	}
+	for _, c := range cases {
+		got, ok := index[c.id]
+		if !ok || *got != c.want {
+			t.Errorf("runeSlotIndex[%s] = %+v, want %+v", c.id, got, c.want)
+		}
+	}
+	if _, ok := index["9999"]; ok {
+		t.Errorf("unknown rune should not be indexed")
+	}
+}
+
+func violationRunes(resp *dto.RespRunePageCheck) map[string][]string {
+	result := make(map[string][]string)
+	for _, v := range resp.Violations {
+		result[v.Rule] = append(result[v.Rule], v.Runes...)
+	}
+	return result
+}
+
+func TestCheckRunePageLOL(t *testing.T) {
+	tree := testLOLRuneTree()
+	cases := []struct {
+		name      string
+		params    RunePageCheckParams
+		primary   string
+		secondary string
+		want      map[string][]string
+	}{
+		{
+			name:      "valid",
+			params:    RunePageCheckParams{Runes: []string{"8010", "9111", "9104", "8014", "8345", "8347", "5005", "5002", "5001"}},
+			primary:   "精密",
+			secondary: "启迪",
+			want:      map[string][]string{},
+		},
+		{
+			name:      "keystone decides primary",
+			params:    RunePageCheckParams{Runes: []string{"8345", "8347", "8010", "9111", "9104", "8014"}},
+			primary:   "精密",
+			secondary: "启迪",
+			want:      map[string][]string{},
+		},
+		{
+			name:    "unknown and duplicate",
+			params:  RunePageCheckParams{Runes: []string{"8010", "9111", "9111", "123"}},
+			primary: "精密",
+			want:    map[string][]string{RuneRuleUnknown: {"123"}, RuneRuleDuplicate: {"9111"}},
+		},
+		{
+			name:      "same style",
+			params:    RunePageCheckParams{Primary: "精密", Secondary: "精密", Runes: []string{"8010", "9111"}},
+			primary:   "精密",
+			secondary: "精密",
+			want:      map[string][]string{RuneRuleSameStyle: nil},
+		},
+		{
+			name:      "style mismatch",
+			params:    RunePageCheckParams{Primary: "精密", Secondary: "主宰", Runes: []string{"8010", "8345"}},
+			primary:   "精密",
+			secondary: "主宰",
+			want:      map[string][]string{RuneRuleStyleMismatch: {"8345"}},
+		},
+		{
+			name:      "same slot and keystone in secondary",
+			params:    RunePageCheckParams{Runes: []string{"8010", "9101", "9111", "8351", "8345"}},
+			primary:   "精密",
+			secondary: "启迪",
+			want:      map[string][]string{RuneRuleKeystoneSecondary: {"8351"}, RuneRuleSameSlot: {"9101", "9111"}},
+		},
+		{
+			name:    "two keystones",
+			params:  RunePageCheckParams{Runes: []string{"8010", "8008"}},
+			primary: "精密",
+			want:    map[string][]string{RuneRuleKeystoneCount: {"8010", "8008"}},
+		},
+		{
+			name:      "no keystone",
+			params:    RunePageCheckParams{Runes: []string{"9111", "8345"}},
+			primary:   "精密",
+			secondary: "启迪",
+			want:      map[string][]string{RuneRuleKeystoneCount: nil},
+		},
+		{
+			name:      "too many runes and shards",
+			params:    RunePageCheckParams{Runes: []string{"8010", "9111", "9104", "8014", "8299", "8306", "8345", "8347", "5005", "5008", "5002", "5001"}},
+			primary:   "精密",
+			secondary: "启迪",
+			want: map[string][]string{
+				RuneRuleSameSlot:   {"8014", "8299"},
+				RuneRuleRuneCount:  {"9111", "9104", "8014", "8299", "8306", "8345", "8347"},
+				RuneRuleShardCount: {"5005", "5008", "5002", "5001"},
+			},
+		},
+	}
+	for _, c := range cases {
+		c.params.Platform = common.PlatformForLOL
+		resp := checkRunePage(tree, &c.params)
+		if resp.Primary != c.primary || resp.Secondary != c.secondary {
+			t.Errorf("%s: primary=%q secondary=%q, want %q %q", c.name, resp.Primary, resp.Secondary, c.primary, c.secondary)
+		}
+		if got := violationRunes(resp); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: violations = %v, want %v", c.name, got, c.want)
+		}
+		if resp.Valid != (len(c.want) == 0) {
+			t.Errorf("%s: valid = %v", c.name, resp.Valid)
+		}
+	}
+}
+
+func TestCheckRunePageLOLM(t *testing.T) {
+	tree := testLOLMRuneTree()
+	cases := []struct {
+		name string
+		ids  []string
+		want map[string][]string
+	}{
+		// 基石和其他系的符文组合，不校验主副系
+		{"valid", []string{"1", "11", "21", "31"}, map[string][]string{}},
+		{"same slot", []string{"1", "11", "12"}, map[string][]string{RuneRuleSameSlot: {"11", "12"}}},
+		{"two keystones", []string{"1", "2", "11"}, map[string][]string{RuneRuleKeystoneCount: {"1", "2"}}},
+		{"no keystone", []string{"11", "21"}, map[string][]string{RuneRuleKeystoneCount: nil}},
+	}
+	for _, c := range cases {
+		resp := checkRunePage(tree, &RunePageCheckParams{Platform: common.PlatformForLOLM, Runes: c.ids})
+		if got := violationRunes(resp); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: violations = %v, want %v", c.name, got, c.want)
+		}
+		if resp.Secondary != "" {
+			t.Errorf("%s: LOLM should not have a secondary style, got %q", c.name, resp.Secondary)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// 召唤师技能可用的模式
+const (
+	SkillModeClassic = "classic" // 召唤师峡谷
+	SkillModeARAM    = "aram"    // 极地大乱斗
+	SkillModeArena   = "arena"   // 斗魂竞技场
+	SkillModeURF     = "urf"     // 无限火力
+)
+
+var SkillModes = []string{SkillModeClassic, SkillModeARAM, SkillModeArena, SkillModeURF}
+
+// SkillModeName 模式的展示名称
+var SkillModeName = map[string]string{
+	SkillModeClassic: "召唤师峡谷",
+	SkillModeARAM:    "极地大乱斗",
+	SkillModeArena:   "斗魂竞技场",
+	SkillModeURF:     "无限火力",
+}
+
+// skillModeAliases 上游 gamemode/mode 字段中各模式可能的写法
+var skillModeAliases = map[string][]string{
+	SkillModeClassic: {"CLASSIC", "召唤师峡谷", "经典"},
+	SkillModeARAM:    {"ARAM", "大乱斗"},
+	SkillModeArena:   {"CHERRY", "ARENA", "竞技场"},
+	SkillModeURF:     {"URF", "无限火力", "无限乱斗"},
+}
+
+// parseSkillModes 解析召唤师技能可用的模式，上游为空或无法识别时视为所有模式可用
+func parseSkillModes(raw string) []string {
+	s := strings.ToUpper(raw)
+	result := make([]string, 0, len(SkillModes))
+	for _, mode := range SkillModes {
+		for _, alias := range skillModeAliases[mode] {
+			if strings.Contains(s, alias) {
+				result = append(result, mode)
+				break
+			}
+		}
+	}
+	if len(result) == 0 {
+		return SkillModes
+	}
+	return result
+}
+
+// loadSkillBriefs 最新版本的召唤师技能，按解锁等级排序
+func loadSkillBriefs(platform int) ([]*dto.SkillBrief, error) {
+	result := make([]*dto.SkillBrief, 0)
+	if platform == common.PlatformForLOL {
+		sd := dao.NewLOLSkillDAO()
+		v, err := sd.GetLOLSkillMaxVersion()
+		if err != nil || v == nil {
+			return result, err
+		}
+		skills, err := sd.GetLOLSkill(v.Version)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range skills {
+			result = append(result, &dto.SkillBrief{
+				ID:          s.SkillID,
+				Name:        s.Name,
+				Icon:        s.Icon,
+				Description: s.Description,
+				Cooldown:    s.Cooldown,
+				UnlockLevel: cast.ToInt(s.Summonerlevel),
+				Modes:       parseSkillModes(s.Gamemode),
+				Version:     s.Version,
+				Platform:    platform,
+			})
+		}
+	} else {
+		sd := dao.NewLOLMSkillDAO()
+		v, err := sd.GetLOLMSkillMaxVersion()
+		if err != nil || v == nil {
+			return result, err
+		}
+		skills, err := sd.GetLOLMSkill(v.Version)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range skills {
+			result = append(result, &dto.SkillBrief{
+				ID:          s.SkillID,
+				Name:        s.Name,
+				Icon:        s.IconPath,
+				Description: s.FuncDesc,
+				Cooldown:    s.Cd,
+				UnlockLevel: cast.ToInt(s.Unlocklv),
+				Modes:       parseSkillModes(s.Mode),
+				Version:     s.Version,
+				Platform:    platform,
+			})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].UnlockLevel != result[j].UnlockLevel {
+			return result[i].UnlockLevel < result[j].UnlockLevel
+		}
+		return cast.ToInt(result[i].ID) < cast.ToInt(result[j].ID)
+	})
+	return result, nil
+}
+
+func skillInMode(skill *dto.SkillBrief, mode string) bool {
+	for _, m := range skill.Modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSkills 召唤师技能列表，mode 为空时不按模式过滤，level 大于0时只返回该等级已解锁的
+func GetSkills(ctx *context.Context, platform int, mode string, level int) ([]*dto.SkillBrief, error) {
+	skills, err := loadSkillBriefs(platform)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dto.SkillBrief, 0, len(skills))
+	for _, skill := range skills {
+		if mode != "" && !skillInMode(skill, mode) {
+			continue
+		}
+		if level > 0 && skill.UnlockLevel > level {
+			continue
+		}
+		result = append(result, skill)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("skills platform:%d mode:%s level:%d total:%d", platform, mode, level, len(result)))
+	return result, nil
+}
+
+// filterSuitSkills 去掉推荐出装中在该模式下不可用的召唤师技能，组内其他技能保留
+func filterSuitSkills(hs *dto.HeroSuit, skills []*dto.SkillBrief, mode string) {
+	available := make(map[int]bool)
+	for _, skill := range skills {
+		if skillInMode(skill, mode) {
+			available[cast.ToInt(skill.ID)] = true
+		}
+	}
+	for pos, suit := range hs.Equips {
+		groups := make([][]*dto.SuitData, 0, len(suit.Skill))
+		for _, group := range suit.Skill {
+			kept := make([]*dto.SuitData, 0, len(group))
+			for _, data := range group {
+				if available[data.ID] {
+					kept = append(kept, data)
+				}
+			}
+			// 去掉后为空的组不保留
+			if len(kept) > 0 {
+				groups = append(groups, kept)
+			}
+		}
+		suit.Skill = groups
+		hs.Equips[pos] = suit
+	}
+}
+
+// GetHeroSuitForMode 英雄在某个模式下的推荐出装，召唤师技能只保留该模式可用的
+func GetHeroSuitForMode(ctx *context.Context, heroID string, tier int, mode string) (dto.HeroSuit, error) {
+	hs, err := GetHeroSuit(ctx, heroID, tier)
+	if err != nil || mode == "" {
+		return hs, err
+	}
+	skills, err := loadSkillBriefs(hs.Platform)
+	if err != nil {
+		return hs, err
+	}
+	if len(skills) == 0 {
+		return hs, errors.New("skill data not found")
+	}
+	filterSuitSkills(&hs, skills, mode)
+	return hs, nil
+}
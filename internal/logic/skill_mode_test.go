@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"whisper/internal/dto"
+)
+
+func TestParseSkillModes(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want []string
+	}{
+		{"CLASSIC", []string{SkillModeClassic}},
+		{"aram", []string{SkillModeARAM}},
+		{"CLASSIC,ARAM,URF", []string{SkillModeClassic, SkillModeARAM, SkillModeURF}},
+		{"CHERRY", []string{SkillModeArena}},
+		{"召唤师峡谷|极地大乱斗", []string{SkillModeClassic, SkillModeARAM}},
+		{"无限乱斗", []string{SkillModeURF}},
+		// 为空或无法识别时视为所有模式可用
+		{"", SkillModes},
+		{"TUTORIAL", SkillModes},
+	}
+	for _, c := range cases {
+		if got := parseSkillModes(c.raw); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseSkillModes(%q) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
+
+func TestSkillInMode(t *testing.T) {
+	skill := &dto.SkillBrief{ID: "4", Modes: []string{SkillModeClassic, SkillModeARAM}}
+	cases := []struct {
+		mode string
+		want bool
+	}{
+		{SkillModeClassic, true},
+		{SkillModeARAM, true},
+		{SkillModeArena, false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := skillInMode(skill, c.mode); got != c.want {
+			t.Errorf("skillInMode(%q) = %v, want %v", c.mode, got, c.want)
+		}
+	}
+}
+
+func TestFilterSuitSkills(t *testing.T) {
+	skills := []*dto.SkillBrief{
+		{ID: "4", Modes: SkillModes},                                 // 闪现
+		{ID: "11", Modes: []string{SkillModeClassic}},                // 惩戒
+		{ID: "32", Modes: []string{SkillModeARAM}},                   // 雪球
+		{ID: "14", Modes: []string{SkillModeClassic, SkillModeARAM}}, // 点燃
+		{ID: "6", Modes: []string{SkillModeClassic, SkillModeArena}}, // 幽灵疾步
+		{ID: "21", Modes: []string{SkillModeClassic}},                // 屏障
+	}
+	groups := func(ids ...[]int) [][]*dto.SuitData {
+		result := make([][]*dto.SuitData, 0, len(ids))
+		for _, group := range ids {
+			datas := make([]*dto.SuitData, 0, len(group))
+			for _, id := range group {
+				datas = append(datas, &dto.SuitData{ID: id})
+			}
+			result = append(result, datas)
+		}
+		return result
+	}
+	ids := func(groups [][]*dto.SuitData) [][]int {
+		result := make([][]int, 0, len(groups))
+		for _, group := range groups {
+			datas := make([]int, 0, len(group))
+			for _, data := range group {
+				datas = append(datas, data.ID)
+			}
+			result = append(result, datas)
+		}
+		return result
+	}
+	cases := []struct {
+		mode string
+		in   [][]int
+		want [][]int
+	}{
+		{SkillModeClassic, [][]int{{4, 11}, {4, 14}}, [][]int{{4, 11}, {4, 14}}},
+		// 只去掉组内不可用的技能，闪现保留
+		{SkillModeARAM, [][]int{{4, 11}, {4, 14}, {4, 32}}, [][]int{{4}, {4, 14}, {4, 32}}},
+		{SkillModeArena, [][]int{{4, 14}, {4, 6}}, [][]int{{4}, {4, 6}}},
+		{SkillModeURF, [][]int{{4, 14}}, [][]int{{4}}},
+		// 空的组以及去掉后为空的组不保留
+		{SkillModeClassic, [][]int{{}, {4, 21}}, [][]int{{4, 21}}},
+		{SkillModeARAM, [][]int{{11, 21}, {4, 32}}, [][]int{{4, 32}}},
+	}
+	for _, c := range cases {
+		hs := &dto.HeroSuit{Equips: map[string]dto.RecommendSuitEquip{
+			"mid": {Skill: groups(c.in...)},
+		}}
+		filterSuitSkills(hs, skills, c.mode)
+		if got := ids(hs.Equips["mid"].Skill); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("filterSuitSkills(%s, %v) = %v, want %v", c.mode, c.in, got, c.want)
+		}
+	}
+}
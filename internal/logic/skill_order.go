@@ -0,0 +1,234 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"regexp"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// skillSlotName 技能槽位，上游可能用 1~4 或 QWER 表示
+var skillSlotName = map[string]string{
+	"1": "Q", "2": "W", "3": "E", "4": "R",
+	"Q": "Q", "W": "W", "E": "E", "R": "R",
+}
+
+var (
+	skillSplitRegex = regexp.MustCompile(`[,&|>＞\s\-]+`)
+	skillOrderRegex = regexp.MustCompile(`([QWEqwe])\s*[>＞]\s*([QWEqwe])(?:\s*[>＞]\s*([QWEqwe]))?`)
+	skillMainRegex  = regexp.MustCompile(`主\s*([QWEqwe])\s*副\s*([QWEqwe])()`)
+)
+
+// skillListKeys 上游记录中技能加点字段可能的名称
+var skillListKeys = []string{"skilllist", "skillid", "skills"}
+
+// parseSkillSequence 将 "1,3,2,1" 或 "Q&E&W&Q" 解析为 [Q E W Q]
+func parseSkillSequence(s string) []string {
+	result := make([]string, 0)
+	for _, token := range skillSplitRegex.Split(strings.TrimSpace(s), -1) {
+		if name, ok := skillSlotName[strings.ToUpper(token)]; ok {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// skillMaxOrder 根据逐级加点计算Q/W/E的主升顺序：先点满的优先，其次是加点多的，最后是先点的
+func skillMaxOrder(seq []string) []string {
+	const maxRank = 5
+	count := make(map[string]int)
+	maxedAt := map[string]int{"Q": len(seq) + 1, "W": len(seq) + 1, "E": len(seq) + 1}
+	first := map[string]int{"Q": len(seq) + 1, "W": len(seq) + 1, "E": len(seq) + 1}
+	for i, s := range seq {
+		if s == "R" {
+			continue
+		}
+		count[s]++
+		if first[s] > i {
+			first[s] = i
+		}
+		if count[s] == maxRank {
+			maxedAt[s] = i
+		}
+	}
+	order := []string{"Q", "W", "E"}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if maxedAt[a] != maxedAt[b] {
+			return maxedAt[a] < maxedAt[b]
+		}
+		if count[a] != count[b] {
+			return count[a] > count[b]
+		}
+		return first[a] < first[b]
+	})
+	return order
+}
+
+// parseSkillOrderFromText 从文字描述中解析主升顺序，如 "主Q副E" 之类的写成 "Q>E>W"
+func parseSkillOrderFromText(text string) []string {
+	m := skillOrderRegex.FindStringSubmatch(text)
+	if m == nil {
+		m = skillMainRegex.FindStringSubmatch(text)
+	}
+	if m == nil {
+		return nil
+	}
+	order := make([]string, 0, 3)
+	for _, s := range m[1:] {
+		if s != "" {
+			order = append(order, strings.ToUpper(s))
+		}
+	}
+	// 补全第三个技能
+	if len(order) == 2 {
+		for _, s := range []string{"Q", "W", "E"} {
+			if s != order[0] && s != order[1] {
+				order = append(order, s)
+			}
+		}
+	}
+	return order
+}
+
+// skillOrdersFromJData 解析LOL的 Skilllist
+func skillOrdersFromJData(skilllist string) []*model.HeroesSkillOrder {
+	records := parseJData(skilllist, func(m map[string]interface{}) bool {
+		for _, key := range skillListKeys {
+			if s, ok := m[key].(string); ok && len(parseSkillSequence(s)) >= 3 {
+				return true
+			}
+		}
+		return false
+	})
+
+	result := make([]*model.HeroesSkillOrder, 0, len(records))
+	for _, r := range records {
+		var seq []string
+		for _, key := range skillListKeys {
+			if s, ok := r.Data[key].(string); ok {
+				if seq = parseSkillSequence(s); len(seq) >= 3 {
+					break
+				}
+			}
+		}
+		hs := &model.HeroesSkillOrder{
+			Pos:        r.Lane,
+			SkillOrder: strings.Join(skillMaxOrder(seq), ","),
+			Sequence:   strings.Join(seq, ","),
+			Igamecnt:   cast.ToInt32(r.Data["igamecnt"]),
+			Wincnt:     cast.ToInt32(r.Data["wincnt"]),
+			Winrate:    cast.ToInt32(r.Data["winrate"]),
+			Showrate:   cast.ToInt32(r.Data["showrate"]),
+		}
+		if hs.Winrate == 0 && hs.Igamecnt > 0 {
+			hs.Winrate = hs.Wincnt * 10000 / hs.Igamecnt
+		}
+		result = append(result, hs)
+	}
+	return result
+}
+
+// skillOrdersFromHeroTech 解析LOLM的 SkillIntroduce，只有主升顺序
+func skillOrdersFromHeroTech(intro dto.SkillIntroduce) []*model.HeroesSkillOrder {
+	result := make([]*model.HeroesSkillOrder, 0)
+	texts := make([]string, 0)
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch data := v.(type) {
+		case string:
+			texts = append(texts, data)
+		case []interface{}:
+			for _, item := range data {
+				walk(item)
+			}
+		case map[string]interface{}:
+			for _, item := range data {
+				walk(item)
+			}
+		}
+	}
+	walk(intro.ContentMap)
+
+	exists := make(map[string]bool)
+	for _, text := range texts {
+		order := parseSkillOrderFromText(text)
+		if len(order) == 0 || exists[strings.Join(order, ",")] {
+			continue
+		}
+		exists[strings.Join(order, ",")] = true
+		result = append(result, &model.HeroesSkillOrder{
+			Pos:        common.PositionNameEN[0],
+			SkillOrder: strings.Join(order, ","),
+		})
+	}
+	return result
+}
+
+// updateHeroesSkillOrder 记录英雄的技能加点推荐，LOL按版本保留，LOLM只保留最新一份
+func updateHeroesSkillOrder(ctx *context.Context, platform int, heroId, version, fileTime string, orders []*model.HeroesSkillOrder) error {
+	if len(orders) == 0 {
+		log.Logger.Warn(ctx, "skill order is nil", "heroId:", heroId)
+		return nil
+	}
+	for _, o := range orders {
+		o.HeroId = heroId
+		o.Platform = platform
+		o.Version = version
+		o.FileTime = fileTime
+		if o.Pos == "" {
+			o.Pos = common.PositionNameEN[0]
+		}
+	}
+
+	delCond := map[string]interface{}{
+		"heroId":   heroId,
+		"platform": platform,
+	}
+	if platform == common.PlatformForLOL {
+		delCond["version"] = version
+	}
+	err := dao.NewHeroesSkillOrderDAO().DeleteAndInsert(delCond, orders)
+	if err != nil {
+		return errors.New("Add HeroesSkillOrder " + err.Error() + ",heroId:" + heroId)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Add HeroesSkillOrder heroId:%s rows:%d", heroId, len(orders)))
+	return nil
+}
+
+// getHeroSkillOrders 获取英雄最新版本的技能加点推荐，key为位置
+func getHeroSkillOrders(platform int, heroID string) (map[string][]*dto.SkillOrder, error) {
+	rows, err := dao.NewHeroesSkillOrderDAO().Find(map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]*dto.SkillOrder)
+	for _, row := range rows {
+		// 按版本倒序，只取最新版本
+		if row.Version != rows[0].Version {
+			break
+		}
+		so := &dto.SkillOrder{
+			Order:    splitTags(row.SkillOrder),
+			Sequence: splitTags(row.Sequence),
+			Igamecnt: row.Igamecnt,
+			Winrate:  row.Winrate,
+			Showrate: row.Showrate,
+			Version:  row.Version,
+		}
+		result[row.Pos] = append(result[row.Pos], so)
+	}
+	return result, nil
+}
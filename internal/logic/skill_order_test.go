@@ -0,0 +1,96 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSkillSequence(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"1,3,2,1,1,4", []string{"Q", "E", "W", "Q", "Q", "R"}},
+		{"Q&E&w&Q", []string{"Q", "E", "W", "Q"}},
+		{"Q > W > E", []string{"Q", "W", "E"}},
+		{"5,x", []string{}},
+		{"", []string{}},
+	}
+	for _, c := range cases {
+		if got := parseSkillSequence(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseSkillSequence(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSkillMaxOrder(t *testing.T) {
+	cases := []struct {
+		name string
+		seq  string
+		want []string
+	}{
+		{"max q then e", "Q,E,W,Q,Q,R,Q,E,Q,R,E,E,E,W,W,R,W,W", []string{"Q", "E", "W"}},
+		// 都没有点满时按加点数量
+		{"by count", "E,Q,E,W,E", []string{"E", "Q", "W"}},
+		// 数量相同按先点的
+		{"by first", "W,Q,E", []string{"W", "Q", "E"}},
+	}
+	for _, c := range cases {
+		if got := skillMaxOrder(parseSkillSequence(c.seq)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: skillMaxOrder = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseSkillOrderFromText(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"加点推荐：Q>E>W", []string{"Q", "E", "W"}},
+		{"主升q＞w", []string{"Q", "W", "E"}},
+		{"主E副Q，有大点大", []string{"E", "Q", "W"}},
+		{"没有加点说明", nil},
+	}
+	for _, c := range cases {
+		if got := parseSkillOrderFromText(c.text); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseSkillOrderFromText(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
+
+func TestSkillOrdersFromJData(t *testing.T) {
+	raw := `{"top":[{"skilllist":"1,3,2,1,1,4,1,3,1,4,3,3,3,2,2,4,2,2","igamecnt":"100","wincnt":"55"}],` +
+		`"mid":"[{\"skillid\":\"Q&W&E\",\"winrate\":\"5100\"}]"}`
+	orders := skillOrdersFromJData(raw)
+	if len(orders) != 2 {
+		t.Fatalf("len = %d, want 2", len(orders))
+	}
+	byPos := make(map[string]string)
+	for _, o := range orders {
+		byPos[o.Pos] = o.SkillOrder
+		if o.Pos == "top" && o.Winrate != 5500 {
+			t.Errorf("top winrate = %d, want 5500", o.Winrate)
+		}
+	}
+	if byPos["top"] != "Q,E,W" || byPos["mid"] != "Q,W,E" {
+		t.Errorf("orders = %v", byPos)
+	}
+}
+
+func TestParseJData(t *testing.T) {
+	match := func(m map[string]interface{}) bool {
+		_, ok := m["id"]
+		return ok
+	}
+	// 位置来自外层的key、记录自带的 lane，或者嵌套的json字符串
+	raw := `{"jungle":[{"id":1},{"lane":"support","id":2}],"other":{"nested":"{\"id\":3}"}}`
+	lanes := make(map[float64]string)
+	for _, r := range parseJData(raw, match) {
+		lanes[r.Data["id"].(float64)] = r.Lane
+	}
+	want := map[float64]string{1: "jungle", 2: "support", 3: ""}
+	if !reflect.DeepEqual(lanes, want) {
+		t.Errorf("lanes = %v, want %v", lanes, want)
+	}
+}
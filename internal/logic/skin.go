@@ -1,17 +1,229 @@
 package logic
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
 	"whisper/internal/model"
 	dao "whisper/internal/model/DAO"
 	"whisper/pkg/context"
+	"whisper/pkg/log"
 )
 
-func GetHeroSkins(ctx *context.Context, platform int, heroID string) ([]*model.HeroSkin, error) {
+// DefaultLatestSkins 最新皮肤默认返回的数量
+const DefaultLatestSkins = 20
+
+var skinFields = []string{
+	"heroId", "skinId", "heroTitle", "heroName", "name", "description", "emblemsName", "mainImg", "iconImg", "loadingImg", "videoImg", "sourceImg",
+	"chromaImg", "chromas", "chromasBelongId", "suitType", "publishTime", "isBase", "platform", "version", "fileTime",
+}
+
+// isChroma 炫彩属于某个皮肤
+func isChroma(skin *model.HeroSkin) bool {
+	return skin.ChromasBelongId != "" && skin.ChromasBelongId != "0"
+}
+
+// GetHeroSkins 英雄的皮肤，withChromas 为 false 时不包含炫彩，与记录炫彩之前的结果一致
+func GetHeroSkins(ctx *context.Context, platform int, heroID string, withChromas bool) ([]*model.HeroSkin, error) {
 	sd := dao.NewHeroSkinDAO()
-	ret, err := sd.Find([]string{
-		"heroId", "skinId", "heroTitle", "heroName", "name", "description", "emblemsName", "mainImg", "iconImg", "loadingImg", "videoImg", "sourceImg", "isBase", "platform", "version", "fileTime",
-	}, map[string]interface{}{
+	ret, err := sd.Find(skinFields, map[string]interface{}{
 		"heroId": heroID,
 	})
-	return ret, err
+	if err != nil || withChromas {
+		return ret, err
+	}
+
+	skins := make([]*model.HeroSkin, 0, len(ret))
+	for _, skin := range ret {
+		if !isChroma(skin) {
+			skins = append(skins, skin)
+		}
+	}
+	return skins, nil
+}
+
+// checkSkinPlatform 只记录了LOL的皮肤，见 recordHeroSkin
+func checkSkinPlatform(platform int) error {
+	if platform != common.PlatformForLOL {
+		return errors.New("skins are only available for LOL")
+	}
+	return nil
+}
+
+// skinYear 上线年份，上游格式为 2006-01-02 15:04:05
+func skinYear(publishTime string) string {
+	if len(publishTime) < 4 {
+		return ""
+	}
+	return publishTime[:4]
+}
+
+func convSkinItem(skin *model.HeroSkin) *dto.SkinItem {
+	return &dto.SkinItem{
+		SkinId:      skin.SkinId,
+		HeroId:      skin.HeroId,
+		HeroName:    skin.HeroName,
+		HeroTitle:   skin.HeroTitle,
+		Name:        skin.Name,
+		IsBase:      skin.IsBase == "1",
+		SuitType:    skin.SuitType,
+		PublishTime: skin.PublishTime,
+		EmblemsName: skin.EmblemsName,
+		Description: skin.Description,
+		MainImg:     skin.MainImg,
+		IconImg:     skin.IconImg,
+		LoadingImg:  skin.LoadingImg,
+		VideoImg:    skin.VideoImg,
+		SourceImg:   skin.SourceImg,
+		Chromas:     make([]*dto.SkinChroma, 0),
+		Platform:    skin.Platform,
+		Version:     skin.Version,
+	}
+}
+
+// loadSkinItems 平台下所有的皮肤，炫彩归到所属的皮肤下
+func loadSkinItems(platform int, heroID string) ([]*dto.SkinItem, error) {
+	cond := map[string]interface{}{
+		"platform": platform,
+	}
+	if heroID != "" {
+		cond["heroId"] = heroID
+	}
+	rows, err := dao.NewHeroSkinDAO().Find(skinFields, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.SkinItem, 0, len(rows))
+	parents := make(map[string]*dto.SkinItem)
+	for _, row := range rows {
+		if isChroma(row) {
+			continue
+		}
+		item := convSkinItem(row)
+		parents[row.SkinId] = item
+		items = append(items, item)
+	}
+	for _, row := range rows {
+		if !isChroma(row) {
+			continue
+		}
+		parent, ok := parents[row.ChromasBelongId]
+		if !ok {
+			continue
+		}
+		parent.Chromas = append(parent.Chromas, &dto.SkinChroma{
+			SkinId:    row.SkinId,
+			Name:      row.Name,
+			ChromaImg: row.ChromaImg,
+			IconImg:   row.IconImg,
+		})
+	}
+	return items, nil
+}
+
+type SkinCatalogParams struct {
+	Platform   int
+	HeroID     string
+	SuitType   string
+	Year       string
+	HasChromas *bool
+	Page       int
+	PageSize   int
+}
+
+// GetSkinCatalog 皮肤图鉴，按上线时间倒序
+func GetSkinCatalog(ctx *context.Context, p *SkinCatalogParams) (*dto.RespSkinCatalog, error) {
+	if err := checkSkinPlatform(p.Platform); err != nil {
+		return nil, err
+	}
+	items, err := loadSkinItems(p.Platform, p.HeroID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RespSkinCatalog{
+		SuitTypes: make([]string, 0),
+		Years:     make([]string, 0),
+		Skins:     make([]*dto.SkinItem, 0),
+	}
+	suitTypes := make(map[string]bool)
+	years := make(map[string]bool)
+	matched := make([]*dto.SkinItem, 0, len(items))
+	for _, item := range items {
+		year := skinYear(item.PublishTime)
+		if item.SuitType != "" && !suitTypes[item.SuitType] {
+			suitTypes[item.SuitType] = true
+			resp.SuitTypes = append(resp.SuitTypes, item.SuitType)
+		}
+		if year != "" && !years[year] {
+			years[year] = true
+			resp.Years = append(resp.Years, year)
+		}
+
+		if p.SuitType != "" && item.SuitType != p.SuitType {
+			continue
+		}
+		if p.Year != "" && year != p.Year {
+			continue
+		}
+		if p.HasChromas != nil && (len(item.Chromas) > 0) != *p.HasChromas {
+			continue
+		}
+		matched = append(matched, item)
+	}
+	sort.Strings(resp.SuitTypes)
+	sort.Sort(sort.Reverse(sort.StringSlice(resp.Years)))
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].PublishTime > matched[j].PublishTime
+	})
+
+	resp.Total = len(matched)
+	start := (p.Page - 1) * p.PageSize
+	if start < len(matched) {
+		end := start + p.PageSize
+		if end > len(matched) {
+			end = len(matched)
+		}
+		resp.Skins = matched[start:end]
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("skin catalog platform:%d suitType:%s year:%s total:%d", p.Platform, p.SuitType, p.Year, resp.Total))
+	return resp, nil
+}
+
+// GetLatestSkins 最新上线的皮肤，不包含原皮
+func GetLatestSkins(ctx *context.Context, platform, limit int) ([]*dto.SkinItem, error) {
+	if err := checkSkinPlatform(platform); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultLatestSkins
+	}
+	items, err := loadSkinItems(platform, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.SkinItem, 0, limit)
+	for _, item := range items {
+		if !item.IsBase && item.PublishTime != "" {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].PublishTime > result[j].PublishTime
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// skinKeywords 皮肤名中去掉英雄称号后的部分，便于按皮肤名搜索
+func skinKeywords(skin *model.HeroSkin) string {
+	name := strings.TrimSpace(strings.ReplaceAll(skin.Name, skin.HeroTitle, ""))
+	return strings.Join([]string{name, skin.HeroTitle, skin.SuitType}, ",")
 }
@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+)
+
+func TestSkinYear(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"2023-08-10 10:00:00", "2023"},
+		{"2019", "2019"},
+		{"", ""},
+		{"20", ""},
+	}
+	for _, c := range cases {
+		if got := skinYear(c.in); got != c.want {
+			t.Errorf("skinYear(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestIsChroma(t *testing.T) {
+	cases := []struct {
+		belong string
+		want   bool
+	}{
+		{"", false},
+		{"0", false},
+		{"1001", true},
+	}
+	for _, c := range cases {
+		if got := isChroma(&model.HeroSkin{ChromasBelongId: c.belong}); got != c.want {
+			t.Errorf("isChroma(%q) = %v, want %v", c.belong, got, c.want)
+		}
+	}
+}
+
+func TestSkinKeywords(t *testing.T) {
+	cases := []struct {
+		skin *model.HeroSkin
+		want string
+	}{
+		{&model.HeroSkin{Name: "星之守护者 阿狸", HeroTitle: "阿狸", SuitType: "星之守护者"}, "星之守护者,阿狸,星之守护者"},
+		{&model.HeroSkin{Name: "阿狸", HeroTitle: "阿狸"}, ",阿狸,"},
+	}
+	for _, c := range cases {
+		if got := skinKeywords(c.skin); got != c.want {
+			t.Errorf("skinKeywords(%q) = %q, want %q", c.skin.Name, got, c.want)
+		}
+	}
+}
+
+func TestCheckSkinPlatform(t *testing.T) {
+	if err := checkSkinPlatform(common.PlatformForLOL); err != nil {
+		t.Errorf("LOL skins should be allowed, got %v", err)
+	}
+	// LOLM 没有记录皮肤
+	if err := checkSkinPlatform(common.PlatformForLOLM); err == nil {
+		t.Errorf("LOLM skins should be rejected")
+	}
+}
+
+func TestConvSkinItem(t *testing.T) {
+	item := convSkinItem(&model.HeroSkin{SkinId: "103001", HeroId: "103", IsBase: "1", PublishTime: "2023-08-10"})
+	if item.SkinId != "103001" || !item.IsBase || item.Chromas == nil || len(item.Chromas) != 0 {
+		t.Errorf("convSkinItem = %+v", item)
+	}
+	if item = convSkinItem(&model.HeroSkin{IsBase: "0"}); item.IsBase {
+		t.Errorf("IsBase should be false")
+	}
+}
@@ -0,0 +1,278 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"whisper/internal/dto"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+const snapshotDateLayout = "2006-01-02"
+
+// 趋势默认查询的天数
+const (
+	DefaultTrendDays  = 30
+	DefaultMoversDays = 7
+)
+
+// 涨跌榜的指标
+const (
+	MoverMetricWin  = "win"
+	MoverMetricPick = "pick"
+	MoverMetricBan  = "ban"
+)
+
+// snapshotDate 把上游的统计时间统一为 2006-01-02，解析失败时用当天
+func snapshotDate(fileTime string) string {
+	s := strings.TrimSpace(fileTime)
+	for _, layout := range []string{"2006-01-02 15:04:05", snapshotDateLayout, "20060102"} {
+		if len(s) < len(layout) {
+			continue
+		}
+		if t, err := time.Parse(layout, s[:len(layout)]); err == nil {
+			return t.Format(snapshotDateLayout)
+		}
+	}
+	return time.Now().Format(snapshotDateLayout)
+}
+
+// saveHeroesPositionSnapshot 记录位置数据的当日快照，同一天重复执行时覆盖
+func saveHeroesPositionSnapshot(ctx *context.Context, delCond map[string]interface{}, rows []*model.HeroesPosition) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	statDate := snapshotDate(rows[0].FileTime)
+	snapshots := make([]*model.HeroesPositionSnapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, &model.HeroesPositionSnapshot{
+			HeroId:        row.HeroId,
+			Pos:           row.Pos,
+			ShowRate:      row.ShowRate,
+			WinRate:       row.WinRate,
+			BanRate:       row.BanRate,
+			Level:         row.Level,
+			Strength:      row.Strength,
+			StrengthLevel: row.StrengthLevel,
+			Platform:      row.Platform,
+			Version:       row.Version,
+			StatDate:      statDate,
+		})
+	}
+
+	cond := map[string]interface{}{"statDate": statDate}
+	for k, v := range delCond {
+		cond[k] = v
+	}
+	err := dao.NewHeroesPositionSnapshotDAO().DeleteAndInsert(cond, snapshots)
+	if err != nil {
+		return errors.New("Add HeroesPositionSnapshot " + err.Error())
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Add HeroesPositionSnapshot date:%s rows:%d", statDate, len(snapshots)))
+	return nil
+}
+
+// suitSnapshots 出装、符文和召唤师技能的快照
+func suitSnapshots(rows []*model.HeroesSuit, statDate string) []*model.HeroesSuitSnapshot {
+	snapshots := make([]*model.HeroesSuitSnapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, &model.HeroesSuitSnapshot{
+			HeroId:   row.HeroId,
+			Pos:      row.Pos,
+			Itemids:  row.Itemids,
+			Runeids:  row.Runeids,
+			Skillids: row.Skillids,
+			Igamecnt: row.Igamecnt,
+			Wincnt:   row.Wincnt,
+			Winrate:  row.Winrate,
+			Allcnt:   row.Allcnt,
+			Showrate: row.Showrate,
+			Type:     row.Type,
+			Platform: row.Platform,
+			Version:  row.Version,
+			StatDate: statDate,
+		})
+	}
+	return snapshots
+}
+
+// saveHeroesSuitSnapshot 记录出装统计数据的当日快照，同一天重复执行时覆盖
+func saveHeroesSuitSnapshot(ctx *context.Context, heroId string, rows []*model.HeroesSuit) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	statDate := snapshotDate(rows[0].FileTime)
+	snapshots := suitSnapshots(rows, statDate)
+	err := dao.NewHeroesSuitSnapshotDAO().DeleteAndInsert(map[string]interface{}{
+		"heroId":   heroId,
+		"platform": rows[0].Platform,
+		"statDate": statDate,
+	}, snapshots)
+	if err != nil {
+		return errors.New("Add HeroesSuitSnapshot " + err.Error() + ",heroId:" + heroId)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Add HeroesSuitSnapshot heroId:%s date:%s rows:%d", heroId, statDate, len(snapshots)))
+	return nil
+}
+
+// trendRange 默认查询截止今天的最近 days 天
+func trendRange(start, end string, days int) (string, string) {
+	if end == "" {
+		end = time.Now().Format(snapshotDateLayout)
+	}
+	if start == "" {
+		t, err := time.Parse(snapshotDateLayout, end)
+		if err != nil {
+			t = time.Now()
+		}
+		start = t.AddDate(0, 0, -days).Format(snapshotDateLayout)
+	}
+	return start, end
+}
+
+// GetHeroTrend 英雄在各位置上胜率、出场率、禁用率随日期的变化
+func GetHeroTrend(ctx *context.Context, platform int, heroID, pos string, tier int, start, end string) (*dto.RespHeroTrend, error) {
+	start, end = trendRange(start, end, DefaultTrendDays)
+	level := tierLevel(platform, tier)
+	cond := map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+		"level":    level,
+	}
+	if pos != "" {
+		cond["pos"] = pos
+	}
+	rows, err := dao.NewHeroesPositionSnapshotDAO().Find(cond, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RespHeroTrend{
+		HeroId: heroID,
+		Level:  level,
+		Start:  start,
+		End:    end,
+		Series: make([]*dto.TrendSeries, 0),
+	}
+	series := make(map[string]*dto.TrendSeries)
+	for _, row := range rows {
+		s, ok := series[row.Pos]
+		if !ok {
+			s = &dto.TrendSeries{Pos: row.Pos, Points: make([]*dto.TrendPoint, 0)}
+			series[row.Pos] = s
+			resp.Series = append(resp.Series, s)
+		}
+		s.Points = append(s.Points, &dto.TrendPoint{
+			Date:     row.StatDate,
+			Version:  row.Version,
+			WinRate:  round2(float64(row.WinRate) / 100),
+			ShowRate: round2(float64(row.ShowRate) / 100),
+			BanRate:  round2(float64(row.BanRate) / 100),
+		})
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("trend hero:%s pos:%s level:%d %s~%s rows:%d", heroID, pos, level, start, end, len(rows)))
+	return resp, nil
+}
+
+func moverMetric(row *model.HeroesPositionSnapshot, metric string) int {
+	switch metric {
+	case MoverMetricPick:
+		return row.ShowRate
+	case MoverMetricBan:
+		return row.BanRate
+	default:
+		return row.WinRate
+	}
+}
+
+// GetHeroMovers 最近 days 天指标变化最大的英雄，比较的是范围内最早和最新的快照
+func GetHeroMovers(ctx *context.Context, platform int, pos string, tier, days int, metric string, limit int) (*dto.RespHeroMovers, error) {
+	if days <= 0 {
+		days = DefaultMoversDays
+	}
+	if metric == "" {
+		metric = MoverMetricWin
+	}
+	start, end := trendRange("", "", days)
+	level := tierLevel(platform, tier)
+	cond := map[string]interface{}{
+		"platform": platform,
+		"level":    level,
+	}
+	if pos != "" {
+		cond["pos"] = pos
+	}
+
+	resp := &dto.RespHeroMovers{
+		Metric:  metric,
+		Level:   level,
+		Risers:  make([]*dto.Mover, 0, limit),
+		Fallers: make([]*dto.Mover, 0, limit),
+	}
+	hpsd := dao.NewHeroesPositionSnapshotDAO()
+	dates, err := hpsd.FindDates(cond, start, end)
+	if err != nil {
+		return nil, err
+	}
+	// 快照不足两天时没有可比较的数据
+	if len(dates) < 2 {
+		log.Logger.Info(ctx, fmt.Sprintf("movers platform:%d level:%d %s~%s snapshots:%d", platform, level, start, end, len(dates)))
+		return resp, nil
+	}
+	from, to := dates[0], dates[len(dates)-1]
+	resp.From, resp.To = from, to
+
+	rows, err := hpsd.Find(cond, from, from)
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[string]*model.HeroesPositionSnapshot)
+	for _, row := range rows {
+		before[row.HeroId+"_"+row.Pos] = row
+	}
+	rows, err = hpsd.Find(cond, to, to)
+	if err != nil {
+		return nil, err
+	}
+
+	movers := make([]*dto.Mover, 0, len(rows))
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		b, ok := before[row.HeroId+"_"+row.Pos]
+		if !ok {
+			continue
+		}
+		f, t := moverMetric(b, metric), moverMetric(row, metric)
+		movers = append(movers, &dto.Mover{
+			HeroId: row.HeroId,
+			Pos:    row.Pos,
+			From:   round2(float64(f) / 100),
+			To:     round2(float64(t) / 100),
+			Delta:  round2(float64(t-f) / 100),
+		})
+		ids = append(ids, row.HeroId)
+	}
+	heroes := heroBriefMap(platform, ids)
+	for _, m := range movers {
+		if hero, ok := heroes[m.HeroId]; ok {
+			m.Name = heroDisplayName(platform, hero)
+			m.Avatar = hero.Avatar
+		}
+	}
+
+	sort.Slice(movers, func(i, j int) bool {
+		return movers[i].Delta > movers[j].Delta
+	})
+	for i := 0; i < len(movers) && i < limit && movers[i].Delta > 0; i++ {
+		resp.Risers = append(resp.Risers, movers[i])
+	}
+	for i := len(movers) - 1; i >= 0 && len(resp.Fallers) < limit && movers[i].Delta < 0; i-- {
+		resp.Fallers = append(resp.Fallers, movers[i])
+	}
+	return resp, nil
+}
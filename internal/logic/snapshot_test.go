@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"testing"
+	"time"
+	"whisper/internal/model"
+)
+
+func TestSnapshotDate(t *testing.T) {
+	today := time.Now().Format(snapshotDateLayout)
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"2023-10-01 12:30:00", "2023-10-01"},
+		{" 2023-10-01 ", "2023-10-01"},
+		{"20231001", "2023-10-01"},
+		{"2023-10-01T12:30:00Z", "2023-10-01"},
+		{"", today},
+		{"unknown", today},
+	}
+	for _, c := range cases {
+		if got := snapshotDate(c.in); got != c.want {
+			t.Errorf("snapshotDate(%q) = %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestTrendRange(t *testing.T) {
+	cases := []struct {
+		start, end string
+		days       int
+		wantStart  string
+		wantEnd    string
+	}{
+		{"2023-09-01", "2023-10-01", 7, "2023-09-01", "2023-10-01"},
+		{"", "2023-10-01", 7, "2023-09-24", "2023-10-01"},
+		{"", "2023-03-01", 30, "2023-01-30", "2023-03-01"},
+	}
+	for _, c := range cases {
+		start, end := trendRange(c.start, c.end, c.days)
+		if start != c.wantStart || end != c.wantEnd {
+			t.Errorf("trendRange(%q, %q, %d) = %s %s, want %s %s", c.start, c.end, c.days, start, end, c.wantStart, c.wantEnd)
+		}
+	}
+
+	start, end := trendRange("", "", 7)
+	if end != time.Now().Format(snapshotDateLayout) {
+		t.Errorf("end = %s, want today", end)
+	}
+	if start != time.Now().AddDate(0, 0, -7).Format(snapshotDateLayout) {
+		t.Errorf("start = %s, want 7 days ago", start)
+	}
+}
+
+func TestMoverMetric(t *testing.T) {
+	row := &model.HeroesPositionSnapshot{WinRate: 5100, ShowRate: 800, BanRate: 120}
+	cases := []struct {
+		metric string
+		want   int
+	}{
+		{MoverMetricWin, 5100},
+		{MoverMetricPick, 800},
+		{MoverMetricBan, 120},
+		{"", 5100},
+	}
+	for _, c := range cases {
+		if got := moverMetric(row, c.metric); got != c.want {
+			t.Errorf("moverMetric(%q) = %d, want %d", c.metric, got, c.want)
+		}
+	}
+}
+
+func TestSuitSnapshots(t *testing.T) {
+	rows := []*model.HeroesSuit{
+		{HeroId: "1", Pos: "mid", Itemids: "3089,3020", Igamecnt: 100, Winrate: 5200, Type: 3, Platform: 0, Version: "13.20"},
+		// LOLM 的推荐带有符文和召唤师技能，没有统计数据
+		{HeroId: "10001", Pos: "中路", Itemids: "6031", Runeids: "5001,5101", Skillids: "4,14", Platform: 1},
+	}
+	got := suitSnapshots(rows, "2023-10-20")
+	if len(got) != 2 {
+		t.Fatalf("suitSnapshots = %d rows, want 2", len(got))
+	}
+	if s := got[0]; s.Itemids != "3089,3020" || s.Igamecnt != 100 || s.Winrate != 5200 || s.Type != 3 || s.StatDate != "2023-10-20" {
+		t.Errorf("LOL snapshot = %+v", s)
+	}
+	if s := got[1]; s.Runeids != "5001,5101" || s.Skillids != "4,14" || s.Platform != 1 {
+		t.Errorf("LOLM snapshot = %+v", s)
+	}
+}
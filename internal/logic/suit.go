@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -106,6 +108,48 @@ func QuerySuitEquip(ctx *context.Context, platform int, heroId string) (any, err
 		if err != nil {
 			return nil, errors.New("updateHeroesSuit:" + err.Error())
 		}
+
+		// reload heroes_matchup 表
+		err = updateHeroesMatchup(ctx, platform, heroId, fightData)
+		if err != nil {
+			return nil, errors.New("updateHeroesMatchup:" + err.Error())
+		}
+
+		// 技能加点等数据来自另一个接口，失败时不影响装备数据
+		var styledetails string
+		jData, err := service.QuerySuitEquipForLOL(ctx, heroId)
+		if err != nil {
+			log.Logger.Warn(ctx, "QuerySuitEquipForLOL:", err, "heroId:", heroId)
+		} else {
+			err = updateHeroesSkillOrder(ctx, platform, heroId, fightData.GameVer, fightData.Date, skillOrdersFromJData(jData.Skilllist))
+			if err != nil {
+				log.Logger.Warn(ctx, "updateHeroesSkillOrder:", err)
+			}
+			styledetails = jData.Styledetails
+
+			// reload heroes_time_winrate 表
+			err = updateHeroesTimeWinrate(ctx, platform, heroId, fightData.GameVer, fightData.Date, timeWinratesFromJData(jData.Timesectionwinrate))
+			if err != nil {
+				log.Logger.Warn(ctx, "updateHeroesTimeWinrate:", err)
+			}
+
+			// reload heroes_synergy 表
+			err = updateHeroesSynergy(ctx, platform, heroId, fightData.GameVer, fightData.Date, synergiesFromJData(heroId, jData.Doublechampiondetails))
+			if err != nil {
+				log.Logger.Warn(ctx, "updateHeroesSynergy:", err)
+			}
+		}
+
+		// reload heroes_rune_page 表
+		briefs, err := loadRuneBriefs(platform)
+		if err != nil {
+			log.Logger.Warn(ctx, "loadRuneBriefs:", err)
+			return fightData, nil
+		}
+		err = updateHeroesRunePage(ctx, platform, heroId, fightData.GameVer, fightData.Date, runePagesFromLOL(fightData, styledetails, briefs))
+		if err != nil {
+			log.Logger.Warn(ctx, "updateHeroesRunePage:", err)
+		}
 		return fightData, nil
 	} else {
 		// common.PlatformForLOLM
@@ -119,6 +163,22 @@ func QuerySuitEquip(ctx *context.Context, platform int, heroId string) (any, err
 			return nil, errors.New("updateLOLMHeroesSuit:" + err.Error())
 		}
 
+		now := time.Now().Format("2006-01-02 15:04:05")
+		err = updateHeroesSkillOrder(ctx, platform, heroId, now, now, skillOrdersFromHeroTech(heroTech.Data.SkillIntroduce))
+		if err != nil {
+			log.Logger.Warn(ctx, "updateHeroesSkillOrder:", err)
+		}
+
+		briefs, err := loadRuneBriefs(platform)
+		if err != nil {
+			log.Logger.Warn(ctx, "loadRuneBriefs:", err)
+		} else {
+			err = updateHeroesRunePage(ctx, platform, heroId, now, now, runePagesFromLOLM(equipTechs, briefs))
+			if err != nil {
+				log.Logger.Warn(ctx, "updateHeroesRunePage:", err)
+			}
+		}
+
 		return []any{
 			heroTech, equipTechs,
 		}, nil
@@ -133,7 +193,13 @@ func getFightData(ctx *context.Context, heroId string) (*dto.ChampionFightData,
 	}
 	for pos, posData := range fightData.List.ChampionLane {
 		equipData := map[string]dto.Itemjson{}
-		tmp := dto.ChampionLaneItem{}
+		tmp := dto.ChampionLaneItem{
+			Perkdetail:  posData.Perkdetail,
+			Igamecnt:    posData.Igamecnt,
+			Wincnt:      posData.Wincnt,
+			Lanewinrate: posData.Lanewinrate,
+			Lanshowrate: posData.Lanshowrate,
+		}
 
 		var err error
 		err = json.Unmarshal([]byte(posData.Itemoutjson), &equipData)
@@ -176,13 +242,21 @@ func updateHeroesPosition(ctx *context.Context, platform int, heroId string, fig
 	hpd := dao.NewHeroesPositionDAO()
 	posData := make([]*model.HeroesPosition, 0, 3)
 	for pos, _ := range fightData.List.ChampionFight {
-		posData = append(posData, &model.HeroesPosition{
+		hp := &model.HeroesPosition{
 			HeroId:   heroId,
 			Pos:      pos,
 			Platform: platform,
 			Version:  fightData.GameVer,
 			FileTime: fightData.Date,
-		})
+		}
+		if lane, ok := fightData.List.ChampionLane[pos]; ok {
+			hp.WinRate = cast.ToInt(lane.Lanewinrate)
+			hp.ShowRate = cast.ToInt(lane.Lanshowrate)
+			if igamecnt := cast.ToInt(lane.Igamecnt); hp.WinRate == 0 && igamecnt > 0 {
+				hp.WinRate = cast.ToInt(lane.Wincnt) * 10000 / igamecnt
+			}
+		}
+		posData = append(posData, hp)
 	}
 	if len(posData) == 0 {
 		log.Logger.Warn(ctx, "posData is nil", "heroId:", heroId)
@@ -196,6 +270,16 @@ func updateHeroesPosition(ctx *context.Context, platform int, heroId string, fig
 		return errors.New("Add LOL HeroesPosition " + err.Error() + ",heroId:" + heroId)
 	}
 	log.Logger.Info(ctx, "Add HeroesPosition heroId:", heroId)
+	clearTierListCache(ctx, platform)
+
+	// 保留每日快照
+	err = saveHeroesPositionSnapshot(ctx, map[string]interface{}{
+		"heroId":   heroId,
+		"platform": platform,
+	}, posData)
+	if err != nil {
+		log.Logger.Warn(ctx, "saveHeroesPositionSnapshot:", err)
+	}
 
 	return nil
 }
@@ -287,6 +371,10 @@ func updateLOLHeroesSuit(ctx *context.Context, heroId string, fightData *dto.Cha
 		log.Logger.Warn(ctx, "posData is nil", "heroId:", heroId)
 		return nil
 	}
+	// 按胜率的置信下界排序，避免小样本的高胜率出装排在前面
+	for _, row := range posData {
+		row.Score = suitScore(row)
+	}
 
 	err := hpd.DeleteAndInsert(map[string]interface{}{
 		"heroId": heroId,
@@ -295,6 +383,12 @@ func updateLOLHeroesSuit(ctx *context.Context, heroId string, fightData *dto.Cha
 		return errors.New("Add LOLM HeroesSuit " + err.Error() + ",heroId:" + heroId)
 	}
 	log.Logger.Info(ctx, "Add LOLM HeroesSuit:", heroId)
+
+	// 保留每日快照
+	err = saveHeroesSuitSnapshot(ctx, heroId, posData)
+	if err != nil {
+		log.Logger.Warn(ctx, "saveHeroesSuitSnapshot:", err)
+	}
 	return nil
 }
 func updateLOLMHeroesSuit(ctx *context.Context, heroId string, heroTech *dto.HeroTech, equipTech map[string]*dto.EquipTech) error {
@@ -435,9 +529,27 @@ func updateLOLMHeroesSuit(ctx *context.Context, heroId string, heroTech *dto.Her
 	}
 	log.Logger.Info(ctx, "Add LOLM HeroesSuit:", heroId)
 
+	// 保留每日快照
+	err = saveHeroesSuitSnapshot(ctx, heroId, hsdata)
+	if err != nil {
+		log.Logger.Warn(ctx, "saveHeroesSuitSnapshot:", err)
+	}
 	return nil
 }
 
+// discardGeneration 重建中途出错没有切换时，删除本次写入的一代
+func discardGeneration(ctx *context.Context, gen *redis.Generation) {
+	if redis.ActiveGeneration(ctx, gen.Namespace).ID == gen.ID {
+		return
+	}
+	deleted, err := gen.Discard(ctx)
+	if err != nil {
+		log.Logger.Warn(ctx, "discard generation:", err, "id:", gen.ID)
+		return
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("discard generation %s:%d, keys:%d", gen.Namespace, gen.ID, deleted))
+}
+
 func SuitData2Redis(ctx *context.Context) error {
 	err := heroesSuits2Redis(ctx)
 	if err != nil {
@@ -456,6 +568,14 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		return err
 	}
 
+	// 写入新的一代，全部成功后再切换
+	gen, err := redis.NewGeneration(ctx, redis.CacheNamespaceSuit)
+	if err != nil {
+		return err
+	}
+	defer discardGeneration(ctx, gen)
+	pipe := redis.RDB.Pipeline()
+
 	// LOL
 	// 获取全部装备
 	ed := dao.NewLOLEquipmentDAO()
@@ -473,7 +593,7 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		key := fmt.Sprintf(redis.KeyCacheEquip, equip.Maps, strconv.Itoa(common.PlatformForLOL), equip.ItemId)
 		value, _ := json.Marshal(equip)
 		mequip[key] = equip
-		redis.RDB.Set(ctx, key, value, redis2.KeepTTL)
+		pipe.Set(ctx, gen.Key(key), value, redis2.KeepTTL)
 	}
 
 	// 获取全部符文
@@ -491,7 +611,7 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		key := fmt.Sprintf(redis.KeyCacheRune, "召唤师峡谷", strconv.Itoa(common.PlatformForLOL), lolRune.RuneID)
 		value, _ := json.Marshal(lolRune)
 		mrune[key] = lolRune
-		redis.RDB.Set(ctx, key, value, redis2.KeepTTL)
+		pipe.Set(ctx, gen.Key(key), value, redis2.KeepTTL)
 	}
 
 	// 获取全部召唤师技能
@@ -509,7 +629,7 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		key := fmt.Sprintf(redis.KeyCacheSkill, "召唤师峡谷", strconv.Itoa(common.PlatformForLOL), lolskill.SkillID)
 		value, _ := json.Marshal(lolskill)
 		mskill[key] = lolskill
-		redis.RDB.Set(ctx, key, value, redis2.KeepTTL)
+		pipe.Set(ctx, gen.Key(key), value, redis2.KeepTTL)
 	}
 
 	// LOLM
@@ -529,7 +649,7 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		key := fmt.Sprintf(redis.KeyCacheEquip, "召唤师峡谷", strconv.Itoa(common.PlatformForLOLM), equip.EquipId) // todo
 		value, _ := json.Marshal(equip)
 		mmequip[key] = equip
-		redis.RDB.Set(ctx, key, value, redis2.KeepTTL)
+		pipe.Set(ctx, gen.Key(key), value, redis2.KeepTTL)
 	}
 
 	// 获取全部符文
@@ -552,7 +672,7 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		key := fmt.Sprintf(redis.KeyCacheRune, "召唤师峡谷", strconv.Itoa(common.PlatformForLOLM), lolmRune.RuneId)
 		value, _ := json.Marshal(lolmRune)
 		mmrune[key] = lolmRune
-		redis.RDB.Set(ctx, key, value, redis2.KeepTTL)
+		pipe.Set(ctx, gen.Key(key), value, redis2.KeepTTL)
 	}
 
 	// 获取全部召唤师技能
@@ -571,7 +691,10 @@ func heroesSuits2Redis(ctx *context.Context) error {
 		key := fmt.Sprintf(redis.KeyCacheSkill, "召唤师峡谷", strconv.Itoa(common.PlatformForLOLM), lolmskill.SkillID)
 		value, _ := json.Marshal(lolmskill)
 		mmskill[key] = lolmskill
-		redis.RDB.Set(ctx, key, value, redis2.KeepTTL)
+		pipe.Set(ctx, gen.Key(key), value, redis2.KeepTTL)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return err
 	}
 
 	// --------------------------------
@@ -651,11 +774,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 												Version:   mrune[key].Version,
 												RuneType:  mrune[key].StyleName,
 
-												Igamecnt: data.Igamecnt,
-												Wincnt:   data.Wincnt,
-												Winrate:  data.Winrate,
-												Allcnt:   data.Allcnt,
-												Showrate: data.Showrate,
+												Igamecnt:  data.Igamecnt,
+												Wincnt:    data.Wincnt,
+												Winrate:   data.Winrate,
+												Allcnt:    data.Allcnt,
+												Showrate:  data.Showrate,
+												Score:     data.Score,
+												LowSample: isLowSample(data.Igamecnt),
 
 												Platform: data.Platform,
 											})
@@ -676,6 +801,8 @@ func heroesSuits2Redis(ctx *context.Context) error {
 												Winrate:      data.Winrate,
 												Allcnt:       data.Allcnt,
 												Showrate:     data.Showrate,
+												Score:        data.Score,
+												LowSample:    isLowSample(data.Igamecnt),
 												Title:        data.Title,
 												Author:       data.Author,
 												AuthorIcon:   data.AuthorIcon,
@@ -717,11 +844,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 												Desc:      mskill[key].Description,
 												Version:   mskill[key].Version,
 
-												Igamecnt: data.Igamecnt,
-												Wincnt:   data.Wincnt,
-												Winrate:  data.Winrate,
-												Allcnt:   data.Allcnt,
-												Showrate: data.Showrate,
+												Igamecnt:  data.Igamecnt,
+												Wincnt:    data.Wincnt,
+												Winrate:   data.Winrate,
+												Allcnt:    data.Allcnt,
+												Showrate:  data.Showrate,
+												Score:     data.Score,
+												LowSample: isLowSample(data.Igamecnt),
 											})
 										}
 									} else {
@@ -740,6 +869,8 @@ func heroesSuits2Redis(ctx *context.Context) error {
 												Winrate:      data.Winrate,
 												Allcnt:       data.Allcnt,
 												Showrate:     data.Showrate,
+												Score:        data.Score,
+												LowSample:    isLowSample(data.Igamecnt),
 												Title:        data.Title,
 												Author:       data.Author,
 												AuthorIcon:   data.AuthorIcon,
@@ -775,11 +906,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Sell:      cast.ToInt(mequip[key].Sell),
 											Version:   mequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 										})
 									} else if data.Platform == common.PlatformForLOLM {
 										// 手游
@@ -799,6 +932,8 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Winrate:      data.Winrate,
 											Allcnt:       data.Allcnt,
 											Showrate:     data.Showrate,
+											Score:        data.Score,
+											LowSample:    isLowSample(data.Igamecnt),
 											Title:        data.Title,
 											Author:       data.Author,
 											AuthorIcon:   data.AuthorIcon,
@@ -834,11 +969,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Sell:      cast.ToInt(mequip[key].Sell),
 											Version:   mequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 										})
 									} else if data.Platform == common.PlatformForLOLM {
 										// 手游
@@ -854,11 +991,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Price:   cast.ToInt(mmequip[key].Price),
 											Version: mmequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 
 											Title:        data.Title,
 											Author:       data.Author,
@@ -896,11 +1035,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Sell:      cast.ToInt(mequip[key].Sell),
 											Version:   mequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 										})
 									} else if data.Platform == common.PlatformForLOLM {
 										// 手游
@@ -917,11 +1058,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Price:   cast.ToInt(mmequip[key].Price),
 											Version: mmequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 
 											Title:        data.Title,
 											Author:       data.Author,
@@ -959,11 +1102,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Sell:      cast.ToInt(mequip[key].Sell),
 											Version:   mequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 										})
 									} else if data.Platform == common.PlatformForLOLM {
 										// 手游
@@ -979,11 +1124,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 											Price:   cast.ToInt(mmequip[key].Price),
 											Version: mmequip[key].Version,
 
-											Igamecnt: data.Igamecnt,
-											Wincnt:   data.Wincnt,
-											Winrate:  data.Winrate,
-											Allcnt:   data.Allcnt,
-											Showrate: data.Showrate,
+											Igamecnt:  data.Igamecnt,
+											Wincnt:    data.Wincnt,
+											Winrate:   data.Winrate,
+											Allcnt:    data.Allcnt,
+											Showrate:  data.Showrate,
+											Score:     data.Score,
+											LowSample: isLowSample(data.Igamecnt),
 
 											Title:        data.Title,
 											Author:       data.Author,
@@ -1014,7 +1161,13 @@ func heroesSuits2Redis(ctx *context.Context) error {
 					}
 
 					jsonData, _ := json.Marshal(eqs)
-					redis.RDB.HSet(ctx, redis.KeyCacheHeroEquip, hero.HeroId, jsonData)
+					// 写入失败的英雄记为失败，这一代不会被切换
+					if err := redis.RDB.HSet(ctx, gen.Key(redis.KeyCacheHeroEquip), hero.HeroId, jsonData).Err(); err != nil {
+						atomic.AddInt32(&taskFail, 1)
+						cancelFunc()
+						log.Logger.Error(ctx, err, "heroId:", hero.HeroId)
+						return
+					}
 					atomic.AddInt32(&taskSucc, 1)
 				}
 			}(hero)
@@ -1028,11 +1181,21 @@ func heroesSuits2Redis(ctx *context.Context) error {
 	log.Logger.Info(ctx, fmt.Sprintf("成功执行了: %d 个任务", taskSucc))
 	log.Logger.Info(ctx, fmt.Sprintf("剩余: %d 个任务待处理", taskAll-taskDone))
 
+	// 有失败时不切换，继续使用上一代的数据，本次写入的数据由 discardGeneration 删除
+	if taskFail > 0 || taskSucc < taskAll {
+		return fmt.Errorf("generation %d not activated, fail:%d succ:%d all:%d", gen.ID, taskFail, taskSucc, taskAll)
+	}
+	deleted, err := gen.Activate(ctx)
+	if err != nil {
+		return err
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("activate generation %s:%d, gc keys:%d", gen.Namespace, gen.ID, deleted))
 	return nil
 }
 
-func GetHeroSuit(ctx *context.Context, heroID string) (dto.HeroSuit, error) {
-	d := redis.RDB.HGet(ctx, redis.KeyCacheHeroEquip, heroID)
+func GetHeroSuit(ctx *context.Context, heroID string, tier int) (dto.HeroSuit, error) {
+	d := redis.RDB.HGet(ctx, redis.ActiveGeneration(ctx, redis.CacheNamespaceSuit).Key(redis.KeyCacheHeroEquip), heroID)
+	redis.RecordCacheHit(ctx, redis.CacheNamespaceSuit, d.Err() == nil)
 	hs := dto.HeroSuit{
 		HeroID: heroID,
 		ExtInfo: dto.HeroSuitExtInfo{
@@ -1055,6 +1218,21 @@ func GetHeroSuit(ctx *context.Context, heroID string) (dto.HeroSuit, error) {
 	err := json.Unmarshal([]byte(d.Val()), &rs)
 	hs.Equips = rs
 
+	skillOrders, err2 := getHeroSkillOrders(hs.Platform, heroID)
+	if err2 != nil {
+		log.Logger.Warn(ctx, "getHeroSkillOrders:", err2, "heroId:", heroID)
+	}
+	hs.SkillOrders = skillOrders
+
+	positions, err2 := getHeroPositions(hs.Platform, tier, []string{heroID})
+	if err2 != nil {
+		log.Logger.Warn(ctx, "getHeroPositions:", err2, "heroId:", heroID)
+	}
+	hs.Positions = make([]*dto.HeroPosition, 0, len(positions[heroID]))
+	for _, row := range positions[heroID] {
+		hs.Positions = append(hs.Positions, convHeroPosition(row))
+	}
+
 	for title, data := range rs {
 		var mTypeEquips map[string][][]*dto.SuitData
 		marshal, _ := json.Marshal(data)
@@ -1100,33 +1278,52 @@ func HeroesPosition(ctx *context.Context, platform int) (*dto.HeroRankList, erro
 		"platform": common.PlatformForLOLM,
 	}
 
+	hp := lolmHeroPositions(rankList)
+	err = hpd.DeleteAndInsert(cond, hp)
+	if err != nil {
+		log.Logger.Error(ctx, err)
+		return nil, err
+	}
+	log.Logger.Info(ctx, "add LOLM position success")
+	clearTierListCache(ctx, common.PlatformForLOLM)
+
+	// 保留每日快照
+	err = saveHeroesPositionSnapshot(ctx, cond, hp)
+	if err != nil {
+		log.Logger.Warn(ctx, "saveHeroesPositionSnapshot:", err)
+	}
+	return rankList, nil
+}
+
+// lolmHeroPositions 保留各个分段的数据
+// 查询时 tier 为0表示未指定分段，取钻石以上（见 tierLevel），上游分段0的数据无法被查询，不保存
+func lolmHeroPositions(rankList *dto.HeroRankList) []*model.HeroesPosition {
 	hp := make([]*model.HeroesPosition, 0)
-	// 只取钻石以上分段
-	if levData, ok := rankList.Data[common.LevelDiamond]; ok {
+	for level, levData := range rankList.Data {
+		if level == common.LevelDefault {
+			continue
+		}
 		for pos, heroes := range levData {
 			posName := common.PositionNameEN[pos]
 			for _, data := range heroes {
 
 				hp = append(hp, &model.HeroesPosition{
-					HeroId:   data.HeroId,
-					Pos:      posName,
-					ShowRate: utils.Str2Int(data.AppearRate),
-					WinRate:  utils.Str2Int(data.WinRate),
-					Platform: common.PlatformForLOLM,
-					Version:  data.Dtstatdate,
-					FileTime: data.Dtstatdate,
+					HeroId:        data.HeroId,
+					Pos:           posName,
+					ShowRate:      utils.Str2Int(data.AppearRate),
+					WinRate:       utils.Str2Int(data.WinRate),
+					BanRate:       utils.Str2Int(data.ForbidRate),
+					Level:         level,
+					Strength:      data.Strength,
+					StrengthLevel: data.StrengthLevel,
+					Platform:      common.PlatformForLOLM,
+					Version:       data.Dtstatdate,
+					FileTime:      data.Dtstatdate,
 				})
 			}
 		}
 	}
-
-	err = hpd.DeleteAndInsert(cond, hp)
-	if err != nil {
-		log.Logger.Error(ctx, err)
-		return nil, err
-	}
-	log.Logger.Info(ctx, "add LOLM position success")
-	return rankList, nil
+	return hp
 }
 
 func inArray(id string, ids []string) bool {
@@ -1147,66 +1344,184 @@ func SuitHeroData2Redis(ctx *context.Context) error {
 	return nil
 }
 
+// 英雄适配度的权重
+const (
+	SuitHeroWinWeight  = 0.6
+	SuitHeroPickWeight = 0.4
+)
+
+// suitHeroAcc 英雄与某个装备（符文、召唤师技能）的统计
+type suitHeroAcc struct {
+	platform int
+	games    int32
+	wins     int32
+	pick     int32 // 包含该装备的出装中最高的出场率
+	count    int   // 推荐次数，没有统计数据时使用
+}
+
+// suitHeroFit 按平台归一化后的适配度（0~100）
+// 有统计数据的平台：胜率置信下界和出场率分别归一化后加权；没有统计数据的平台按推荐次数归一化
+func suitHeroFit(accs map[string]map[string]*suitHeroAcc) map[string]map[string]float64 {
+	type bound struct {
+		minWin, maxWin, minPick, maxPick float64
+		maxCount                         int
+		hasStats                         bool
+	}
+	bounds := make(map[int]*bound)
+	for _, heroes := range accs {
+		for _, acc := range heroes {
+			b, ok := bounds[acc.platform]
+			if !ok {
+				b = &bound{minWin: math.MaxFloat64, minPick: math.MaxFloat64}
+				bounds[acc.platform] = b
+			}
+			if acc.count > b.maxCount {
+				b.maxCount = acc.count
+			}
+			if acc.games == 0 {
+				continue
+			}
+			b.hasStats = true
+			win, pick := float64(wilsonLowerBound(acc.wins, acc.games)), float64(acc.pick)
+			b.minWin, b.maxWin = math.Min(b.minWin, win), math.Max(b.maxWin, win)
+			b.minPick, b.maxPick = math.Min(b.minPick, pick), math.Max(b.maxPick, pick)
+		}
+	}
+
+	norm := func(v, min, max float64) float64 {
+		if max <= min {
+			return 100
+		}
+		return (v - min) / (max - min) * 100
+	}
+	result := make(map[string]map[string]float64, len(accs))
+	for key, heroes := range accs {
+		result[key] = make(map[string]float64, len(heroes))
+		for heroID, acc := range heroes {
+			b := bounds[acc.platform]
+			var fit float64
+			if b.hasStats && acc.games == 0 {
+				// 有统计数据的平台中缺少样本的推荐排在最后
+				fit = 0
+			} else if b.hasStats {
+				win := norm(float64(wilsonLowerBound(acc.wins, acc.games)), b.minWin, b.maxWin)
+				pick := norm(float64(acc.pick), b.minPick, b.maxPick)
+				fit = SuitHeroWinWeight*win + SuitHeroPickWeight*pick
+			} else {
+				fit = norm(float64(acc.count), 0, float64(b.maxCount))
+			}
+			result[key][heroID] = round2(fit)
+		}
+	}
+	return result
+}
+
 func suitHero2Redis(ctx *context.Context) error {
 	hsd := dao.NewHeroesSuitDAO()
 	list, err := hsd.FindHighRateEquip([]string{
-		"heroId", "itemids", "skillids", "runeids", "winrate", "showrate", "platform", "author", "version", "fileTime",
+		"heroId", "itemids", "skillids", "runeids", "igamecnt", "wincnt", "winrate", "showrate", "platform", "author", "version", "fileTime",
 	}, nil)
 	if err != nil {
 		return err
 	}
 
-	var (
-		ch = make(chan struct{}, 5000)
-		wg = sync.WaitGroup{}
-	)
-
+	// 先在内存中按 key、英雄汇总
+	accs := make(map[string]map[string]*suitHeroAcc)
+	add := func(key string, hero *model.HeroesSuit) {
+		if _, ok := accs[key]; !ok {
+			accs[key] = make(map[string]*suitHeroAcc)
+		}
+		acc, ok := accs[key][hero.HeroId]
+		if !ok {
+			acc = &suitHeroAcc{platform: hero.Platform}
+			accs[key][hero.HeroId] = acc
+		}
+		acc.games += hero.Igamecnt
+		acc.wins += suitWins(hero)
+		if hero.Showrate > acc.pick {
+			acc.pick = hero.Showrate
+		}
+		acc.count++
+	}
 	for _, hero := range list {
-		wg.Add(1)
-		ch <- struct{}{}
-
-		go func(hero *model.HeroesSuit) {
-			defer func() {
-				wg.Done()
-				<-ch
-			}()
-
-			// equip
-			if hero.Itemids != "" {
-				ids := strings.Split(hero.Itemids, ",")
-				for _, id := range ids {
-					key := fmt.Sprintf(redis.KeyCacheEquipHeroSuit, hero.Platform, id)
-					redis.RDB.ZIncrBy(ctx, key, 1, hero.HeroId)
-				}
+		// equip
+		if hero.Itemids != "" {
+			for _, id := range strings.Split(hero.Itemids, ",") {
+				add(fmt.Sprintf(redis.KeyCacheEquipHeroSuit, hero.Platform, id), hero)
 			}
-
-			// rune
-			if hero.Runeids != "" {
-				ids := strings.Split(hero.Runeids, ",")
-				for _, id := range ids {
-					key := fmt.Sprintf(redis.KeyCacheRuneHeroSuit, hero.Platform, id)
-					redis.RDB.ZIncrBy(ctx, key, 1, hero.HeroId)
-				}
+		}
+		// rune
+		if hero.Runeids != "" {
+			for _, id := range strings.Split(hero.Runeids, ",") {
+				add(fmt.Sprintf(redis.KeyCacheRuneHeroSuit, hero.Platform, id), hero)
 			}
-
-			// skill
-			if hero.Skillids != "" {
-				ids := strings.Split(hero.Skillids, ",")
-				for _, id := range ids {
-					key := fmt.Sprintf(redis.KeyCacheSkillHeroSuit, hero.Platform, id)
-					redis.RDB.ZIncrBy(ctx, key, 1, hero.HeroId)
-				}
+		}
+		// skill
+		if hero.Skillids != "" {
+			for _, id := range strings.Split(hero.Skillids, ",") {
+				add(fmt.Sprintf(redis.KeyCacheSkillHeroSuit, hero.Platform, id), hero)
 			}
-		}(hero)
+		}
+	}
+	fits := suitHeroFit(accs)
 
+	// 每次都全量写入新的一代，写完后再切换
+	gen, err := redis.NewGeneration(ctx, redis.CacheNamespaceSuitHero)
+	if err != nil {
+		return err
+	}
+	defer discardGeneration(ctx, gen)
+	pipe := redis.RDB.Pipeline()
+	for key, heroes := range fits {
+		members := make([]redis2.Z, 0, len(heroes))
+		games := make(map[string]interface{})
+		for heroID, fit := range heroes {
+			members = append(members, redis2.Z{Score: fit, Member: heroID})
+			if g := accs[key][heroID].games; g > 0 {
+				games[heroID] = g
+			}
+		}
+		pipe.ZAdd(ctx, gen.Key(key), members...)
+		if len(games) > 0 {
+			pipe.HSet(ctx, gen.Key(fmt.Sprintf(redis.KeyCacheSuitHeroGames, key)), games)
+		}
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return err
+	}
+	deleted, err := gen.Activate(ctx)
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
-	log.Logger.Info(ctx, "suitHero2Redis ok")
+	log.Logger.Info(ctx, fmt.Sprintf("suitHero2Redis ok, generation:%d keys:%d gc keys:%d", gen.ID, len(fits), deleted))
 	return nil
 }
 
-func GetEquipHeroSuit(ctx *context.Context, platform int, equipID string) ([]*dto.SearchResultList, error) {
+// rankSuitHeroes 按缓存中的适配度排序，并标记样本少的英雄
+func rankSuitHeroes(ctx *context.Context, gen *redis.Generation, key string, scores []redis2.Z, heroes []*dto.SearchResultList) []*dto.SearchResultList {
+	order := make(map[string]int, len(scores))
+	score := make(map[string]float64, len(scores))
+	for i, z := range scores {
+		member := z.Member.(string)
+		order[member] = i
+		score[member] = z.Score
+	}
+
+	games := redis.RDB.HGetAll(ctx, gen.Key(fmt.Sprintf(redis.KeyCacheSuitHeroGames, key))).Val()
+	for _, hero := range heroes {
+		hero.Score = score[hero.Id]
+		if g, ok := games[hero.Id]; ok {
+			hero.LowSample = isLowSample(cast.ToInt32(g))
+		}
+	}
+	sort.SliceStable(heroes, func(i, j int) bool {
+		return order[heroes[i].Id] < order[heroes[j].Id]
+	})
+	return heroes
+}
+
+func GetEquipHeroSuit(ctx *context.Context, platform int, equipID string, tier int) ([]*dto.SearchResultList, error) {
 	// 获取英雄适配数据
 	suitHeroes := make([]*dto.SearchResultList, 0)
 
@@ -1214,7 +1529,8 @@ func GetEquipHeroSuit(ctx *context.Context, platform int, equipID string) ([]*dt
 	max := "+inf"
 	// ZREVRANGE my_rankings 0 2 WITHSCORES
 	key := fmt.Sprintf(redis.KeyCacheEquipHeroSuit, platform, equipID)
-	score := redis.RDB.ZRevRangeByScoreWithScores(ctx, key, &redis2.ZRangeBy{
+	gen := redis.ActiveGeneration(ctx, redis.CacheNamespaceSuitHero)
+	score := redis.RDB.ZRevRangeByScoreWithScores(ctx, gen.Key(key), &redis2.ZRangeBy{
 		Min: min,
 		Max: max,
 		//Offset: 0,
@@ -1226,6 +1542,7 @@ func GetEquipHeroSuit(ctx *context.Context, platform int, equipID string) ([]*dt
 		heroesID = append(heroesID, k.Member.(string))
 	}
 
+	redis.RecordCacheHit(ctx, redis.CacheNamespaceSuitHero, len(heroesID) > 0)
 	if len(heroesID) == 0 {
 		return suitHeroes, nil
 	}
@@ -1256,10 +1573,11 @@ func GetEquipHeroSuit(ctx *context.Context, platform int, equipID string) ([]*dt
 		})
 	}
 
-	return suitHeroes, nil
+	suitHeroes = rankSuitHeroes(ctx, gen, key, score.Val(), suitHeroes)
+	return filterHeroesByTier(ctx, platform, tier, suitHeroes), nil
 }
 
-func GetRuneHeroSuit(ctx *context.Context, platform int, runeID string) ([]*dto.SearchResultList, error) {
+func GetRuneHeroSuit(ctx *context.Context, platform int, runeID string, tier int) ([]*dto.SearchResultList, error) {
 	// 获取英雄适配数据
 	suitHeroes := make([]*dto.SearchResultList, 0)
 
@@ -1267,7 +1585,8 @@ func GetRuneHeroSuit(ctx *context.Context, platform int, runeID string) ([]*dto.
 	max := "+inf"
 	// ZREVRANGE my_rankings 0 2 WITHSCORES
 	key := fmt.Sprintf(redis.KeyCacheRuneHeroSuit, platform, runeID)
-	score := redis.RDB.ZRevRangeByScoreWithScores(ctx, key, &redis2.ZRangeBy{
+	gen := redis.ActiveGeneration(ctx, redis.CacheNamespaceSuitHero)
+	score := redis.RDB.ZRevRangeByScoreWithScores(ctx, gen.Key(key), &redis2.ZRangeBy{
 		Min: min,
 		Max: max,
 		//Offset: 0,
@@ -1279,6 +1598,7 @@ func GetRuneHeroSuit(ctx *context.Context, platform int, runeID string) ([]*dto.
 		heroesID = append(heroesID, k.Member.(string))
 	}
 
+	redis.RecordCacheHit(ctx, redis.CacheNamespaceSuitHero, len(heroesID) > 0)
 	if len(heroesID) == 0 {
 		return suitHeroes, nil
 	}
@@ -1309,10 +1629,11 @@ func GetRuneHeroSuit(ctx *context.Context, platform int, runeID string) ([]*dto.
 		})
 	}
 
-	return suitHeroes, nil
+	suitHeroes = rankSuitHeroes(ctx, gen, key, score.Val(), suitHeroes)
+	return filterHeroesByTier(ctx, platform, tier, suitHeroes), nil
 }
 
-func GetSkillHeroSuit(ctx *context.Context, platform int, skillID string) ([]*dto.SearchResultList, error) {
+func GetSkillHeroSuit(ctx *context.Context, platform int, skillID string, tier int) ([]*dto.SearchResultList, error) {
 	// 获取英雄适配数据
 	suitHeroes := make([]*dto.SearchResultList, 0)
 
@@ -1320,7 +1641,8 @@ func GetSkillHeroSuit(ctx *context.Context, platform int, skillID string) ([]*dt
 	max := "+inf"
 	// ZREVRANGE my_rankings 0 2 WITHSCORES
 	key := fmt.Sprintf(redis.KeyCacheSkillHeroSuit, platform, skillID)
-	score := redis.RDB.ZRevRangeByScoreWithScores(ctx, key, &redis2.ZRangeBy{
+	gen := redis.ActiveGeneration(ctx, redis.CacheNamespaceSuitHero)
+	score := redis.RDB.ZRevRangeByScoreWithScores(ctx, gen.Key(key), &redis2.ZRangeBy{
 		Min: min,
 		Max: max,
 		//Offset: 0,
@@ -1332,6 +1654,7 @@ func GetSkillHeroSuit(ctx *context.Context, platform int, skillID string) ([]*dt
 		heroesID = append(heroesID, k.Member.(string))
 	}
 
+	redis.RecordCacheHit(ctx, redis.CacheNamespaceSuitHero, len(heroesID) > 0)
 	if len(heroesID) == 0 {
 		return suitHeroes, nil
 	}
@@ -1362,5 +1685,6 @@ func GetSkillHeroSuit(ctx *context.Context, platform int, skillID string) ([]*dt
 		})
 	}
 
-	return suitHeroes, nil
+	suitHeroes = rankSuitHeroes(ctx, gen, key, score.Val(), suitHeroes)
+	return filterHeroesByTier(ctx, platform, tier, suitHeroes), nil
 }
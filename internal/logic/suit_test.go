@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/logic/common"
+)
+
+func TestSuitHeroFit(t *testing.T) {
+	accs := map[string]map[string]*suitHeroAcc{
+		"equip:0:3031": {
+			// 胜率下界最高，出场率最低
+			"1": {platform: common.PlatformForLOL, games: 30000, wins: 16500, pick: 500},
+			// 胜率下界最低，出场率最高
+			"2": {platform: common.PlatformForLOL, games: 3, wins: 3, pick: 2500},
+			"3": {platform: common.PlatformForLOL, count: 1},
+		},
+		"equip:0:1038": {
+			"1": {platform: common.PlatformForLOL, games: 1000, wins: 500, pick: 1500},
+		},
+		// 手游没有统计数据，按推荐次数归一化
+		"equip:1:1": {
+			"10": {platform: common.PlatformForLOLM, count: 4},
+			"11": {platform: common.PlatformForLOLM, count: 1},
+		},
+	}
+	fits := suitHeroFit(accs)
+
+	cases := []struct {
+		key, hero string
+		want      float64
+	}{
+		{"equip:0:3031", "1", 60},
+		{"equip:0:3031", "2", 40},
+		{"equip:0:3031", "3", 0},
+		// 1000场50%的下界约为46.91%，在 43.85%~54.44% 的约29%处，出场率在 500~2500 的中间
+		{"equip:0:1038", "1", 37.34},
+		{"equip:1:1", "10", 100},
+		{"equip:1:1", "11", 25},
+	}
+	for _, c := range cases {
+		if got := fits[c.key][c.hero]; got != c.want {
+			t.Errorf("fit[%s][%s] = %v, want %v", c.key, c.hero, got, c.want)
+		}
+	}
+}
+
+func TestSuitHeroFitSameValues(t *testing.T) {
+	// 所有英雄数据相同时都是满分
+	fits := suitHeroFit(map[string]map[string]*suitHeroAcc{
+		"rune:0:8005": {
+			"1": {platform: common.PlatformForLOL, games: 100, wins: 50, pick: 1000},
+			"2": {platform: common.PlatformForLOL, games: 100, wins: 50, pick: 1000},
+		},
+	})
+	for hero, fit := range fits["rune:0:8005"] {
+		if fit != 100 {
+			t.Errorf("fit[%s] = %v, want 100", hero, fit)
+		}
+	}
+}
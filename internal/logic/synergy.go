@@ -0,0 +1,305 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"sort"
+	"whisper/internal/dto"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// DefaultSynergyMinGames 组合数据默认的最小样本数，也用于计算组合分时的收缩
+const DefaultSynergyMinGames = 50
+
+// synergyPartnerKeys 上游记录中队友ID字段可能的名称
+var synergyPartnerKeys = []string{"championid2", "doublechampionid", "partnerid", "teammateid"}
+
+// synergyPartnerPosKeys 上游记录中队友位置字段可能的名称
+var synergyPartnerPosKeys = []string{"lane2", "doublelane", "partnerlane"}
+
+// synergyPartnerPos 上游没有给出队友位置时，按英雄的位置推断：下路与辅助，中单、上单与打野
+var synergyPartnerPos = map[string]string{
+	"bottom":  "support",
+	"support": "bottom",
+	"mid":     "jungle",
+	"top":     "jungle",
+}
+
+func synergyPartnerKey(m map[string]interface{}) string {
+	for _, key := range synergyPartnerKeys {
+		if cast.ToString(m[key]) != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// isPartnerIDMap 以队友ID为key的对象
+func isPartnerIDMap(m map[string]interface{}) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for k, v := range m {
+		if _, err := cast.ToIntE(k); err != nil {
+			return false
+		}
+		if _, ok := v.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// synergiesFromJData 解析LOL的 Doublechampiondetails
+func synergiesFromJData(heroID, raw string) []*model.HeroesSynergy {
+	records := parseJData(raw, func(m map[string]interface{}) bool {
+		return synergyPartnerKey(m) != "" || isPartnerIDMap(m)
+	})
+
+	result := make([]*model.HeroesSynergy, 0)
+	exists := make(map[string]bool)
+	add := func(pos, partnerID string, data map[string]interface{}) {
+		if partnerID == "" || partnerID == heroID {
+			return
+		}
+		if pos == "" {
+			pos = cast.ToString(data["lane"])
+		}
+		hs := &model.HeroesSynergy{
+			Pos:       pos,
+			PartnerId: partnerID,
+			Igamecnt:  cast.ToInt32(data["igamecnt"]),
+			Wincnt:    cast.ToInt32(data["wincnt"]),
+			Winrate:   cast.ToInt32(data["winrate"]),
+		}
+		for _, key := range synergyPartnerPosKeys {
+			if s := cast.ToString(data[key]); s != "" {
+				hs.PartnerPos = s
+				break
+			}
+		}
+		if hs.PartnerPos == "" {
+			hs.PartnerPos = synergyPartnerPos[hs.Pos]
+		}
+		if hs.Winrate == 0 && hs.Igamecnt > 0 {
+			hs.Winrate = hs.Wincnt * 10000 / hs.Igamecnt
+		}
+		if exists[hs.Pos+hs.PartnerId+hs.PartnerPos] {
+			return
+		}
+		exists[hs.Pos+hs.PartnerId+hs.PartnerPos] = true
+		result = append(result, hs)
+	}
+
+	for _, r := range records {
+		if key := synergyPartnerKey(r.Data); key != "" {
+			add(r.Lane, cast.ToString(r.Data[key]), r.Data)
+			continue
+		}
+		for partnerID, v := range r.Data {
+			add(r.Lane, partnerID, v.(map[string]interface{}))
+		}
+	}
+	return result
+}
+
+// updateHeroesSynergy 按版本记录英雄的队友组合数据
+func updateHeroesSynergy(ctx *context.Context, platform int, heroId, version, fileTime string, rows []*model.HeroesSynergy) error {
+	if len(rows) == 0 {
+		log.Logger.Warn(ctx, "synergy is nil", "heroId:", heroId)
+		return nil
+	}
+	for _, row := range rows {
+		row.HeroId = heroId
+		row.Platform = platform
+		row.Version = version
+		row.FileTime = fileTime
+	}
+
+	err := dao.NewHeroesSynergyDAO().DeleteAndInsert(map[string]interface{}{
+		"heroId":   heroId,
+		"platform": platform,
+		"version":  version,
+	}, rows)
+	if err != nil {
+		return errors.New("Add HeroesSynergy " + err.Error() + ",heroId:" + heroId)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Add HeroesSynergy heroId:%s rows:%d", heroId, len(rows)))
+	return nil
+}
+
+// synergyVersion version为空时取库中最新的版本
+func synergyVersion(platform int, version string) (string, error) {
+	if version != "" {
+		return version, nil
+	}
+	v, err := dao.NewHeroesSynergyDAO().GetMaxVersion(platform)
+	if err != nil {
+		return "", err
+	}
+	if v == nil {
+		return "", errors.New("synergy data is empty")
+	}
+	return v.Version, nil
+}
+
+// synergyBaseline 英雄与所有队友组合的加权平均胜率（万分比）
+func synergyBaseline(rows []*model.HeroesSynergy) float64 {
+	var games, wins float64
+	for _, row := range rows {
+		games += float64(row.Igamecnt)
+		wins += float64(row.Winrate) * float64(row.Igamecnt)
+	}
+	if games == 0 {
+		return 0
+	}
+	return wins / games
+}
+
+func convPartner(platform int, row *model.HeroesSynergy, heroID, pos string, baseline float64, heroes map[string]*model.HeroAttribute) *dto.Partner {
+	p := &dto.Partner{
+		HeroId:   heroID,
+		Pos:      pos,
+		Games:    row.Igamecnt,
+		Wins:     row.Wincnt,
+		WinRate:  round2(float64(row.Winrate) / 100),
+		Synergy:  round2((float64(row.Winrate) - baseline) / 100),
+		Platform: platform,
+	}
+	if hero, ok := heroes[heroID]; ok {
+		p.Name = heroDisplayName(platform, hero)
+		p.Avatar = hero.Avatar
+	}
+	return p
+}
+
+// GetHeroPartners 英雄的最佳队友，按组合胜率排序
+func GetHeroPartners(ctx *context.Context, platform int, heroID, pos, version string, minGames, limit int) (*dto.RespHeroPartners, error) {
+	version, err := synergyVersion(platform, version)
+	if err != nil {
+		return nil, err
+	}
+	if minGames <= 0 {
+		minGames = DefaultSynergyMinGames
+	}
+
+	cond := map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+		"version":  version,
+	}
+	if pos != "" {
+		cond["pos"] = pos
+	}
+	rows, err := dao.NewHeroesSynergyDAO().Find(cond)
+	if err != nil {
+		return nil, err
+	}
+	baseline := synergyBaseline(rows)
+
+	partners := make([]*model.HeroesSynergy, 0, limit)
+	ids := make([]string, 0, limit)
+	for _, row := range rows {
+		if row.Igamecnt < int32(minGames) {
+			continue
+		}
+		partners = append(partners, row)
+		ids = append(ids, row.PartnerId)
+		if limit > 0 && len(partners) >= limit {
+			break
+		}
+	}
+	heroes := heroBriefMap(platform, ids)
+
+	resp := &dto.RespHeroPartners{
+		HeroId:   heroID,
+		Pos:      pos,
+		Version:  version,
+		Baseline: round2(baseline / 100),
+		Partners: make([]*dto.Partner, 0, len(partners)),
+	}
+	for _, row := range partners {
+		resp.Partners = append(resp.Partners, convPartner(platform, row, row.PartnerId, row.PartnerPos, baseline, heroes))
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("partners hero:%s pos:%s version:%s partners:%d", heroID, pos, version, len(resp.Partners)))
+	return resp, nil
+}
+
+// GetPairScore 两个英雄作为队友的组合评分
+// 协同为组合胜率减去heroID的平均组合胜率，评分按样本数收缩：synergy * games / (games + DefaultSynergyMinGames)
+func GetPairScore(ctx *context.Context, platform int, heroID, partnerID, version string) (*dto.RespPairScore, error) {
+	version, err := synergyVersion(platform, version)
+	if err != nil {
+		return nil, err
+	}
+
+	hsd := dao.NewHeroesSynergyDAO()
+	rows, err := hsd.Find(map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+		"version":  version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	self, other := heroID, partnerID
+	pair := findPartner(rows, partnerID)
+	if pair == nil {
+		// 上游只在其中一个英雄的数据中给出了组合，反过来再查一次
+		rows, err = hsd.Find(map[string]interface{}{
+			"heroId":   partnerID,
+			"platform": platform,
+			"version":  version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		self, other = partnerID, heroID
+		pair = findPartner(rows, heroID)
+	}
+	if pair == nil {
+		return nil, errors.New("pair data is empty")
+	}
+
+	// 平均胜率只统计同一位置的组合
+	samePos := make([]*model.HeroesSynergy, 0, len(rows))
+	for _, row := range rows {
+		if row.Pos == pair.Pos {
+			samePos = append(samePos, row)
+		}
+	}
+	baseline := synergyBaseline(samePos)
+	heroes := heroBriefMap(platform, []string{self, other})
+
+	resp := &dto.RespPairScore{
+		Version: version,
+		Hero:    convPartner(platform, pair, self, pair.Pos, baseline, heroes),
+		Partner: convPartner(platform, pair, other, pair.PartnerPos, baseline, heroes),
+		Games:   pair.Igamecnt,
+		WinRate: round2(float64(pair.Winrate) / 100),
+		Synergy: round2((float64(pair.Winrate) - baseline) / 100),
+	}
+	resp.Score = round2(resp.Synergy * float64(pair.Igamecnt) / float64(pair.Igamecnt+DefaultSynergyMinGames))
+	return resp, nil
+}
+
+// findPartner 同一个队友可能出现在多个位置组合中，取样本最多的
+func findPartner(rows []*model.HeroesSynergy, partnerID string) *model.HeroesSynergy {
+	matched := make([]*model.HeroesSynergy, 0)
+	for _, row := range rows {
+		if row.PartnerId == partnerID {
+			matched = append(matched, row)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Igamecnt > matched[j].Igamecnt
+	})
+	return matched[0]
+}
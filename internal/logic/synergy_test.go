@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"math"
+	"testing"
+	"whisper/internal/model"
+)
+
+func TestSynergyPartnerKey(t *testing.T) {
+	cases := []struct {
+		in   map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"championid2": 412, "winrate": 5100}, "championid2"},
+		{map[string]interface{}{"partnerid": "412"}, "partnerid"},
+		{map[string]interface{}{"partnerid": ""}, ""},
+		{map[string]interface{}{"championid": 22}, ""},
+	}
+	for _, c := range cases {
+		if got := synergyPartnerKey(c.in); got != c.want {
+			t.Errorf("synergyPartnerKey(%v) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestIsPartnerIDMap(t *testing.T) {
+	cases := []struct {
+		in   map[string]interface{}
+		want bool
+	}{
+		{map[string]interface{}{"412": map[string]interface{}{"winrate": 5100}}, true},
+		{map[string]interface{}{}, false},
+		{map[string]interface{}{"412": 5100}, false},
+		{map[string]interface{}{"lane": map[string]interface{}{}}, false},
+	}
+	for _, c := range cases {
+		if got := isPartnerIDMap(c.in); got != c.want {
+			t.Errorf("isPartnerIDMap(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSynergiesFromJData(t *testing.T) {
+	raw := `{"bottom":[
+		{"championid2":412,"igamecnt":200,"wincnt":110},
+		{"championid2":412,"igamecnt":200,"wincnt":110},
+		{"championid2":22,"igamecnt":100,"winrate":4800,"lane2":"mid"},
+		{"championid2":51,"igamecnt":100}
+	],"mid":{"64":{"igamecnt":50,"wincnt":30}}}`
+	rows := synergiesFromJData("51", raw)
+
+	got := make(map[string]*model.HeroesSynergy)
+	for _, row := range rows {
+		got[row.Pos+"/"+row.PartnerId+"/"+row.PartnerPos] = row
+	}
+	// 重复的记录去重，队友是自己的记录跳过
+	if len(rows) != 3 {
+		t.Fatalf("len = %d, want 3: %v", len(rows), got)
+	}
+	cases := []struct {
+		key     string
+		winrate int32
+	}{
+		// 没有队友位置时，下路的队友默认是辅助
+		{"bottom/412/support", 5500},
+		{"bottom/22/mid", 4800},
+		{"mid/64/jungle", 6000},
+	}
+	for _, c := range cases {
+		row, ok := got[c.key]
+		if !ok {
+			t.Errorf("missing %s in %v", c.key, got)
+			continue
+		}
+		if row.Winrate != c.winrate {
+			t.Errorf("%s winrate = %d, want %d", c.key, row.Winrate, c.winrate)
+		}
+	}
+}
+
+func TestSynergyBaseline(t *testing.T) {
+	cases := []struct {
+		rows []*model.HeroesSynergy
+		want float64
+	}{
+		{nil, 0},
+		{[]*model.HeroesSynergy{{Igamecnt: 0, Winrate: 5000}}, 0},
+		{[]*model.HeroesSynergy{{Igamecnt: 300, Winrate: 5000}, {Igamecnt: 100, Winrate: 5400}}, 5100},
+	}
+	for _, c := range cases {
+		if got := synergyBaseline(c.rows); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("synergyBaseline = %v, want %v", got, c.want)
+		}
+	}
+}
+
+func TestFindPartner(t *testing.T) {
+	rows := []*model.HeroesSynergy{
+		{PartnerId: "412", PartnerPos: "support", Igamecnt: 100},
+		{PartnerId: "22", Igamecnt: 500},
+		{PartnerId: "412", PartnerPos: "jungle", Igamecnt: 300},
+	}
+	if got := findPartner(rows, "412"); got == nil || got.PartnerPos != "jungle" {
+		t.Errorf("findPartner should pick the row with most games, got %+v", got)
+	}
+	if got := findPartner(rows, "1"); got != nil {
+		t.Errorf("findPartner(1) = %+v, want nil", got)
+	}
+}
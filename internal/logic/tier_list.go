@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"whisper/internal/dto"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+	"whisper/pkg/redis"
+)
+
+// 评分公式的默认值
+const (
+	DefaultTierWinWeight   = 0.6
+	DefaultTierPickWeight  = 0.25
+	DefaultTierBanWeight   = 0.15
+	DefaultTierMinShowRate = 50
+)
+
+const tierListCacheTTL = time.Hour * 6
+
+// tierThresholds 按综合分（标准分）划分梯队，依次为 S/A/B/C，其余为 D
+var tierThresholds = []struct {
+	Tier  string
+	Score float64
+}{
+	{"S", 1.0},
+	{"A", 0.4},
+	{"B", -0.4},
+	{"C", -1.0},
+}
+
+const tierLowest = "D"
+
+type TierListParams struct {
+	Platform int
+	Version  string
+	Pos      string
+	Level    int
+	Formula  dto.TierFormula
+}
+
+// tierOf 综合分所在的梯队
+func tierOf(score float64) string {
+	for _, t := range tierThresholds {
+		if score >= t.Score {
+			return t.Tier
+		}
+	}
+	return tierLowest
+}
+
+// zScores 标准分，所有值相同（如LOL没有禁用率）时都为0
+func zScores(values []float64) []float64 {
+	result := make([]float64, len(values))
+	if len(values) == 0 {
+		return result
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / float64(len(values)))
+	if std == 0 {
+		return result
+	}
+	for i, v := range values {
+		result[i] = (v - mean) / std
+	}
+	return result
+}
+
+// scoreTierHeroes 计算同一位置下英雄的综合分
+func scoreTierHeroes(rows []*model.HeroesPosition, f dto.TierFormula) []float64 {
+	wins := make([]float64, len(rows))
+	picks := make([]float64, len(rows))
+	bans := make([]float64, len(rows))
+	for i, row := range rows {
+		wins[i] = float64(row.WinRate)
+		picks[i] = float64(row.ShowRate)
+		bans[i] = float64(row.BanRate)
+	}
+	zw, zp, zb := zScores(wins), zScores(picks), zScores(bans)
+
+	total := f.WinWeight + f.PickWeight + f.BanWeight
+	scores := make([]float64, len(rows))
+	if total == 0 {
+		return scores
+	}
+	for i := range rows {
+		scores[i] = (f.WinWeight*zw[i] + f.PickWeight*zp[i] + f.BanWeight*zb[i]) / total
+	}
+	return scores
+}
+
+func tierListCacheKey(p *TierListParams) string {
+	formula := fmt.Sprintf("%g_%g_%g_%d", p.Formula.WinWeight, p.Formula.PickWeight, p.Formula.BanWeight, p.Formula.MinShowRate)
+	return fmt.Sprintf(redis.KeyCacheTierList, p.Platform, p.Version, p.Pos, p.Level, formula)
+}
+
+// clearTierListCache 英雄位置数据更新后删除该平台的梯队缓存
+func clearTierListCache(ctx *context.Context, platform int) {
+	n, err := redis.DeleteKeys(ctx, fmt.Sprintf(redis.KeyCacheTierListAll, platform))
+	if err != nil {
+		log.Logger.Warn(ctx, "clearTierListCache:", err)
+		return
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("clear tier list cache platform:%d keys:%d", platform, n))
+}
+
+// GetTierList 按位置和段位生成英雄梯队，结果缓存在redis
+func GetTierList(ctx *context.Context, p *TierListParams) (*dto.RespTierList, error) {
+	hpd := dao.NewHeroesPositionDAO()
+	if p.Version == "" {
+		v, err := hpd.GetMaxVersion(p.Platform)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, errors.New("heroes position is empty")
+		}
+		p.Version = v.Version
+	}
+	p.Level = tierLevel(p.Platform, p.Level)
+
+	key := tierListCacheKey(p)
+	if data, err := redis.RDB.Get(ctx, key).Result(); err == nil {
+		resp := &dto.RespTierList{}
+		if err = json.Unmarshal([]byte(data), resp); err == nil {
+			return resp, nil
+		}
+		log.Logger.Warn(ctx, "tier list cache:", err)
+	}
+
+	cond := map[string]interface{}{
+		"platform": p.Platform,
+		"version":  p.Version,
+		"level":    p.Level,
+	}
+	if p.Pos != "" {
+		cond["pos"] = p.Pos
+	}
+	rows, err := hpd.Find(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按位置分组，在同一位置内比较
+	posRows := make(map[string][]*model.HeroesPosition)
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.ShowRate < p.Formula.MinShowRate {
+			continue
+		}
+		posRows[row.Pos] = append(posRows[row.Pos], row)
+		ids = append(ids, row.HeroId)
+	}
+	heroes := heroBriefMap(p.Platform, ids)
+
+	groups := make(map[string]*dto.TierGroup)
+	resp := &dto.RespTierList{
+		Platform: p.Platform,
+		Version:  p.Version,
+		Pos:      p.Pos,
+		Level:    p.Level,
+		Formula:  p.Formula,
+		Tiers:    make([]*dto.TierGroup, 0, len(tierThresholds)+1),
+	}
+	for _, t := range tierThresholds {
+		groups[t.Tier] = &dto.TierGroup{Tier: t.Tier, Heroes: make([]*dto.TierHero, 0)}
+		resp.Tiers = append(resp.Tiers, groups[t.Tier])
+	}
+	groups[tierLowest] = &dto.TierGroup{Tier: tierLowest, Heroes: make([]*dto.TierHero, 0)}
+	resp.Tiers = append(resp.Tiers, groups[tierLowest])
+
+	for pos, pr := range posRows {
+		scores := scoreTierHeroes(pr, p.Formula)
+		for i, row := range pr {
+			th := &dto.TierHero{
+				HeroId:   row.HeroId,
+				Pos:      pos,
+				WinRate:  round2(float64(row.WinRate) / 100),
+				ShowRate: round2(float64(row.ShowRate) / 100),
+				BanRate:  round2(float64(row.BanRate) / 100),
+				Score:    round3(scores[i]),
+
+				StrengthLevel: row.StrengthLevel,
+			}
+			if hero, ok := heroes[row.HeroId]; ok {
+				th.Name = heroDisplayName(p.Platform, hero)
+				th.Avatar = hero.Avatar
+			}
+			group := groups[tierOf(scores[i])]
+			group.Heroes = append(group.Heroes, th)
+		}
+	}
+	for _, group := range resp.Tiers {
+		sort.Slice(group.Heroes, func(i, j int) bool {
+			return group.Heroes[i].Score > group.Heroes[j].Score
+		})
+	}
+
+	s, _ := json.Marshal(resp)
+	redis.RDB.Set(ctx, key, s, tierListCacheTTL)
+	log.Logger.Info(ctx, fmt.Sprintf("tier list platform:%d version:%s pos:%s level:%d heroes:%d", p.Platform, p.Version, p.Pos, p.Level, len(ids)))
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+package logic
+
+import (
+	"math"
+	"testing"
+	"whisper/internal/dto"
+	"whisper/internal/model"
+)
+
+func TestTierOf(t *testing.T) {
+	cases := []struct {
+		score float64
+		want  string
+	}{
+		{2.5, "S"},
+		{1.0, "S"},
+		{0.99, "A"},
+		{0, "B"},
+		{-0.4, "B"},
+		{-0.5, "C"},
+		{-1.01, "D"},
+	}
+	for _, c := range cases {
+		if got := tierOf(c.score); got != c.want {
+			t.Errorf("tierOf(%v) = %s, want %s", c.score, got, c.want)
+		}
+	}
+}
+
+func TestZScores(t *testing.T) {
+	cases := []struct {
+		in   []float64
+		want []float64
+	}{
+		{nil, []float64{}},
+		{[]float64{5000, 5000}, []float64{0, 0}},
+		{[]float64{4800, 5200}, []float64{-1, 1}},
+		{[]float64{1, 2, 3}, []float64{-math.Sqrt(1.5), 0, math.Sqrt(1.5)}},
+	}
+	for _, c := range cases {
+		got := zScores(c.in)
+		if len(got) != len(c.want) {
+			t.Errorf("zScores(%v) = %v, want %v", c.in, got, c.want)
+			continue
+		}
+		for i := range got {
+			if math.Abs(got[i]-c.want[i]) > 1e-9 {
+				t.Errorf("zScores(%v) = %v, want %v", c.in, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestScoreTierHeroes(t *testing.T) {
+	rows := []*model.HeroesPosition{
+		{HeroId: "1", WinRate: 5200, ShowRate: 100},
+		{HeroId: "2", WinRate: 4800, ShowRate: 300},
+	}
+	cases := []struct {
+		name    string
+		formula dto.TierFormula
+		want    []float64
+	}{
+		// 禁用率都为0时不影响综合分
+		{"win only", dto.TierFormula{WinWeight: 1, BanWeight: 1}, []float64{0.5, -0.5}},
+		{"pick only", dto.TierFormula{PickWeight: 1}, []float64{-1, 1}},
+		{"balanced", dto.TierFormula{WinWeight: 1, PickWeight: 1}, []float64{0, 0}},
+		{"no weight", dto.TierFormula{}, []float64{0, 0}},
+	}
+	for _, c := range cases {
+		got := scoreTierHeroes(rows, c.formula)
+		for i := range got {
+			if math.Abs(got[i]-c.want[i]) > 1e-9 {
+				t.Errorf("%s: scoreTierHeroes = %v, want %v", c.name, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestTierListCacheKey(t *testing.T) {
+	p := &TierListParams{
+		Platform: 1,
+		Version:  "1.0",
+		Pos:      "mid",
+		Level:    2,
+		Formula:  dto.TierFormula{WinWeight: 0.6, PickWeight: 0.25, BanWeight: 0.15, MinShowRate: 50},
+	}
+	want := "cache:tier_list:1:1.0:mid:2:0.6_0.25_0.15_50"
+	if got := tierListCacheKey(p); got != want {
+		t.Errorf("tierListCacheKey = %s, want %s", got, want)
+	}
+	p.Formula.WinWeight = 0.7
+	if got := tierListCacheKey(p); got == want {
+		t.Errorf("different formulas should use different keys")
+	}
+}
@@ -0,0 +1,288 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cast"
+	"regexp"
+	"strings"
+	"whisper/internal/dto"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+	dao "whisper/internal/model/DAO"
+	"whisper/pkg/context"
+	"whisper/pkg/log"
+)
+
+// timeSectionKeys 上游记录中对局时长字段可能的名称
+var timeSectionKeys = []string{"timesection", "time_section", "section", "gametime", "playtime", "time"}
+
+// timeRangeRegex 对局时长区间，如 "0-20"、"25~30"、"40+"、"<20"
+var timeRangeRegex = regexp.MustCompile(`^\s*[<＜]?\s*(\d+)\s*(?:[-~]\s*\d+)?\s*(?:\+|min|分钟)?\s*$`)
+
+func isTimeRange(s string) bool {
+	return timeRangeRegex.MatchString(s)
+}
+
+// timeSectionStart 区间开始的分钟数，"<20" 从0开始
+func timeSectionStart(section string) int {
+	m := timeRangeRegex.FindStringSubmatch(section)
+	if m == nil {
+		return 0
+	}
+	if s := strings.TrimSpace(section); strings.HasPrefix(s, "<") || strings.HasPrefix(s, "＜") {
+		return 0
+	}
+	return cast.ToInt(m[1])
+}
+
+// normWinrate 胜率统一为万分比，上游可能是小数、百分比或万分比
+func normWinrate(v interface{}) int32 {
+	f := cast.ToFloat64(v)
+	switch {
+	case f <= 1:
+		return int32(f * 10000)
+	case f <= 100:
+		return int32(f * 100)
+	default:
+		return int32(f)
+	}
+}
+
+func timeSectionKey(m map[string]interface{}) string {
+	for _, key := range timeSectionKeys {
+		if s := cast.ToString(m[key]); s != "" && isTimeRange(s) {
+			return key
+		}
+	}
+	return ""
+}
+
+// timeWinratesFromJData 解析LOL的 Timesectionwinrate
+// 记录可能是 {"timesection":"25-30","igamecnt":..,"winrate":..}，也可能是 {"25-30":5012,...}
+func timeWinratesFromJData(raw string) []*model.HeroesTimeWinrate {
+	records := parseJData(raw, func(m map[string]interface{}) bool {
+		if timeSectionKey(m) != "" {
+			return true
+		}
+		n := 0
+		for k := range m {
+			if isTimeRange(k) {
+				n++
+			}
+		}
+		return n >= 2
+	})
+
+	result := make([]*model.HeroesTimeWinrate, 0)
+	exists := make(map[string]bool)
+	add := func(pos, section string, data map[string]interface{}) {
+		if pos == "" {
+			pos = common.PositionNameEN[0]
+		}
+		if exists[pos+section] {
+			return
+		}
+		exists[pos+section] = true
+		tw := &model.HeroesTimeWinrate{
+			Pos:         pos,
+			TimeSection: strings.TrimSpace(section),
+			StartMinute: timeSectionStart(section),
+			Igamecnt:    cast.ToInt32(data["igamecnt"]),
+			Wincnt:      cast.ToInt32(data["wincnt"]),
+		}
+		if _, ok := data["winrate"]; ok {
+			tw.Winrate = normWinrate(data["winrate"])
+		} else if tw.Igamecnt > 0 {
+			tw.Winrate = tw.Wincnt * 10000 / tw.Igamecnt
+		}
+		result = append(result, tw)
+	}
+
+	for _, r := range records {
+		if key := timeSectionKey(r.Data); key != "" {
+			add(r.Lane, cast.ToString(r.Data[key]), r.Data)
+			continue
+		}
+		for section, v := range r.Data {
+			if !isTimeRange(section) {
+				continue
+			}
+			if m, ok := v.(map[string]interface{}); ok {
+				add(r.Lane, section, m)
+			} else {
+				add(r.Lane, section, map[string]interface{}{"winrate": v})
+			}
+		}
+	}
+	return result
+}
+
+// classifyGamePhase 按场次加权计算前/中/后期的胜率，胜率最高的时期即英雄的强势期
+// 只有一个时期有数据时无法判断，返回空
+func classifyGamePhase(rows []*model.HeroesTimeWinrate) string {
+	games := make(map[string]float64)
+	wins := make(map[string]float64)
+	for _, row := range rows {
+		weight := float64(row.Igamecnt)
+		if weight == 0 {
+			weight = 1
+		}
+		phase := common.GamePhaseOf(row.StartMinute)
+		games[phase] += weight
+		wins[phase] += weight * float64(row.Winrate)
+	}
+	if len(games) < 2 {
+		return ""
+	}
+
+	var best string
+	var maxRate, minRate float64
+	for _, phase := range []string{common.GamePhaseEarly, common.GamePhaseMid, common.GamePhaseLate} {
+		if games[phase] == 0 {
+			continue
+		}
+		rate := wins[phase] / games[phase]
+		if best == "" {
+			best, maxRate, minRate = phase, rate, rate
+			continue
+		}
+		if rate > maxRate {
+			best, maxRate = phase, rate
+		}
+		if rate < minRate {
+			minRate = rate
+		}
+	}
+	if maxRate-minRate < common.GamePhaseMinSpread {
+		return common.GamePhaseMid
+	}
+	return best
+}
+
+// heroPhaseRows 计算英雄整体强势期用的数据，有全部位置的数据时只用它，避免重复统计
+func heroPhaseRows(rows []*model.HeroesTimeWinrate) []*model.HeroesTimeWinrate {
+	all := make([]*model.HeroesTimeWinrate, 0)
+	for _, row := range rows {
+		if row.Pos == common.PositionNameEN[0] {
+			all = append(all, row)
+		}
+	}
+	if len(all) > 0 {
+		return all
+	}
+	return rows
+}
+
+// updateHeroesTimeWinrate 按版本记录英雄不同对局时长的胜率
+func updateHeroesTimeWinrate(ctx *context.Context, platform int, heroId, version, fileTime string, rows []*model.HeroesTimeWinrate) error {
+	if len(rows) == 0 {
+		log.Logger.Warn(ctx, "time winrate is nil", "heroId:", heroId)
+		return nil
+	}
+	for _, row := range rows {
+		row.HeroId = heroId
+		row.Platform = platform
+		row.Version = version
+		row.FileTime = fileTime
+	}
+
+	delCond := map[string]interface{}{
+		"heroId":   heroId,
+		"platform": platform,
+		"version":  version,
+	}
+	err := dao.NewHeroesTimeWinrateDAO().DeleteAndInsert(delCond, rows)
+	if err != nil {
+		return errors.New("Add HeroesTimeWinrate " + err.Error() + ",heroId:" + heroId)
+	}
+	log.Logger.Info(ctx, fmt.Sprintf("Add HeroesTimeWinrate heroId:%s rows:%d", heroId, len(rows)))
+	return nil
+}
+
+// GetHeroTimeWinrate 英雄的胜率-对局时长曲线，version为空时取最新版本
+func GetHeroTimeWinrate(ctx *context.Context, platform int, heroID, pos, version string) (*dto.RespHeroTimeWinrate, error) {
+	cond := map[string]interface{}{
+		"heroId":   heroID,
+		"platform": platform,
+	}
+	if version != "" {
+		cond["version"] = version
+	}
+	rows, err := dao.NewHeroesTimeWinrateDAO().Find(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RespHeroTimeWinrate{
+		HeroId: heroID,
+		Lanes:  make([]*dto.LaneTimeWinrate, 0),
+	}
+	if len(rows) == 0 {
+		return resp, nil
+	}
+	resp.Version = rows[0].Version
+
+	latest := make([]*model.HeroesTimeWinrate, 0, len(rows))
+	lanes := make(map[string]*dto.LaneTimeWinrate)
+	laneRows := make(map[string][]*model.HeroesTimeWinrate)
+	for _, row := range rows {
+		// 按版本倒序，只取最新版本
+		if row.Version != resp.Version {
+			break
+		}
+		latest = append(latest, row)
+		if pos != "" && row.Pos != pos {
+			continue
+		}
+		lane, ok := lanes[row.Pos]
+		if !ok {
+			lane = &dto.LaneTimeWinrate{Pos: row.Pos}
+			lanes[row.Pos] = lane
+			resp.Lanes = append(resp.Lanes, lane)
+		}
+		lane.Points = append(lane.Points, &dto.TimeWinratePoint{
+			Section:     row.TimeSection,
+			StartMinute: row.StartMinute,
+			Games:       row.Igamecnt,
+			WinRate:     round2(float64(row.Winrate) / 100),
+		})
+		laneRows[row.Pos] = append(laneRows[row.Pos], row)
+	}
+	for _, lane := range resp.Lanes {
+		lane.Phase = classifyGamePhase(laneRows[lane.Pos])
+	}
+	resp.Phase = classifyGamePhase(heroPhaseRows(latest))
+
+	return resp, nil
+}
+
+// heroGamePhases 批量获取英雄最新版本的强势期，key为英雄ID
+func heroGamePhases(platform int, heroIDs []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(heroIDs) == 0 {
+		return result, nil
+	}
+	rows, err := dao.NewHeroesTimeWinrateDAO().Find(map[string]interface{}{
+		"heroId":   heroIDs,
+		"platform": platform,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	heroRows := make(map[string][]*model.HeroesTimeWinrate)
+	for _, row := range rows {
+		// 按版本倒序，每个英雄只取最新版本
+		if hr := heroRows[row.HeroId]; len(hr) > 0 && hr[0].Version != row.Version {
+			continue
+		}
+		heroRows[row.HeroId] = append(heroRows[row.HeroId], row)
+	}
+	for heroID, hr := range heroRows {
+		if phase := classifyGamePhase(heroPhaseRows(hr)); phase != "" {
+			result[heroID] = phase
+		}
+	}
+	return result, nil
+}
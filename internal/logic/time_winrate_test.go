@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"testing"
+	"whisper/internal/logic/common"
+	"whisper/internal/model"
+)
+
+func TestTimeSection(t *testing.T) {
+	cases := []struct {
+		in    string
+		ok    bool
+		start int
+	}{
+		{"0-20", true, 0},
+		{"25~30", true, 25},
+		{" 30 - 35 ", true, 30},
+		{"40+", true, 40},
+		{"<20", true, 0},
+		{"＜20", true, 0},
+		{"35分钟", true, 35},
+		{"winrate", false, 0},
+		{"", false, 0},
+	}
+	for _, c := range cases {
+		if got := isTimeRange(c.in); got != c.ok {
+			t.Errorf("isTimeRange(%q) = %v, want %v", c.in, got, c.ok)
+		}
+		if got := timeSectionStart(c.in); got != c.start {
+			t.Errorf("timeSectionStart(%q) = %d, want %d", c.in, got, c.start)
+		}
+	}
+}
+
+func TestNormWinrate(t *testing.T) {
+	cases := []struct {
+		in   interface{}
+		want int32
+	}{
+		{0.5123, 5123},
+		{"51.23", 5123},
+		{5123, 5123},
+		{nil, 0},
+	}
+	for _, c := range cases {
+		if got := normWinrate(c.in); got != c.want {
+			t.Errorf("normWinrate(%v) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+func TestTimeSectionKey(t *testing.T) {
+	cases := []struct {
+		in   map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"timesection": "25-30", "winrate": 0.5}, "timesection"},
+		{map[string]interface{}{"time": "40+"}, "time"},
+		// 值不是时长区间的字段不算
+		{map[string]interface{}{"time": "2023-01-01"}, ""},
+		{map[string]interface{}{"25-30": 5012}, ""},
+	}
+	for _, c := range cases {
+		if got := timeSectionKey(c.in); got != c.want {
+			t.Errorf("timeSectionKey(%v) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestTimeWinratesFromJData(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want map[string]int32
+	}{
+		{
+			"records",
+			`{"top":[{"timesection":"0-25","igamecnt":100,"wincnt":55},{"timesection":"25-35","winrate":0.49}]}`,
+			map[string]int32{"top0-25": 5500, "top25-35": 4900},
+		},
+		{
+			"section keys",
+			`[{"0-25":5012,"25-35":{"winrate":48.5,"igamecnt":10}}]`,
+			map[string]int32{"all0-25": 5012, "all25-35": 4850},
+		},
+		{"no section", `[{"winrate":0.5}]`, map[string]int32{}},
+	}
+	for _, c := range cases {
+		got := make(map[string]int32)
+		for _, row := range timeWinratesFromJData(c.raw) {
+			got[row.Pos+row.TimeSection] = row.Winrate
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+			continue
+		}
+		for k, v := range c.want {
+			if got[k] != v {
+				t.Errorf("%s: %s = %d, want %d", c.name, k, got[k], v)
+			}
+		}
+	}
+}
+
+func timeRow(pos string, start int, games, winrate int32) *model.HeroesTimeWinrate {
+	return &model.HeroesTimeWinrate{Pos: pos, StartMinute: start, Igamecnt: games, Winrate: winrate}
+}
+
+func TestClassifyGamePhase(t *testing.T) {
+	cases := []struct {
+		name string
+		rows []*model.HeroesTimeWinrate
+		want string
+	}{
+		{"empty", nil, ""},
+		{"one phase", []*model.HeroesTimeWinrate{timeRow("all", 0, 10, 5500), timeRow("all", 20, 10, 5300)}, ""},
+		{"late", []*model.HeroesTimeWinrate{timeRow("all", 0, 10, 4700), timeRow("all", 25, 10, 5000), timeRow("all", 35, 10, 5400)}, common.GamePhaseLate},
+		{"early", []*model.HeroesTimeWinrate{timeRow("all", 0, 10, 5400), timeRow("all", 35, 10, 4800)}, common.GamePhaseEarly},
+		// 按场次加权：前期场次多的低胜率拉低了前期
+		{"weighted", []*model.HeroesTimeWinrate{timeRow("all", 0, 90, 4800), timeRow("all", 20, 10, 6000), timeRow("all", 30, 10, 5100)}, common.GamePhaseMid},
+		{"flat", []*model.HeroesTimeWinrate{timeRow("all", 0, 10, 5000), timeRow("all", 35, 10, 5050)}, common.GamePhaseMid},
+	}
+	for _, c := range cases {
+		if got := classifyGamePhase(c.rows); got != c.want {
+			t.Errorf("%s: classifyGamePhase = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestHeroPhaseRows(t *testing.T) {
+	top := timeRow("top", 0, 10, 5000)
+	all := timeRow("all", 0, 20, 5100)
+	if got := heroPhaseRows([]*model.HeroesTimeWinrate{top, all}); len(got) != 1 || got[0] != all {
+		t.Errorf("heroPhaseRows should only keep the all position rows, got %v", got)
+	}
+	if got := heroPhaseRows([]*model.HeroesTimeWinrate{top}); len(got) != 1 || got[0] != top {
+		t.Errorf("heroPhaseRows should fall back to all rows, got %v", got)
+	}
+}
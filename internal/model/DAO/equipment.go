@@ -19,6 +19,7 @@ type LOLEquipment interface {
 	GetLOLEquipment(version string) ([]*model.LOLEquipment, error)
 	GetLOLEquipmentWithExt(version string) ([]*model.LOLEquipment, error)
 	GetRoadmap(version string, id string, maps []string) (map[string][]*model.LOLEquipment, error)
+	GetRoadmapEquips(version string, maps []string) ([]*model.LOLEquipment, error)
 }
 
 type LOLEquipmentDAO struct {
@@ -121,6 +122,21 @@ func (dao *LOLEquipmentDAO) GetRoadmap(version string, id string, maps []string)
 	return result, nil
 }
 
+// GetRoadmapEquips 获取版本下所有装备，用于在内存中构建完整的合成树
+func (dao *LOLEquipmentDAO) GetRoadmapEquips(version string, maps []string) ([]*model.LOLEquipment, error) {
+	cond := map[string]interface{}{
+		"status":  0,
+		"version": version,
+	}
+	if len(maps) > 0 {
+		cond["maps"] = maps
+	}
+
+	var equip []*model.LOLEquipment
+	tx := dao.db.Model(model.LOLEquipment{}).Where(cond).Order("id asc").Find(&equip)
+	return equip, tx.Error
+}
+
 func (dao *LOLEquipmentDAO) GetLOLEquipmentWithExt(version string) ([]*model.LOLEquipment, error) {
 
 	notin := strings.Join(config.EquipDict.Exclude, ",")
@@ -181,6 +197,7 @@ type LOLMEquipment interface {
 	GetLOLMEquipment(version string) ([]*model.LOLMEquipment, error)
 	GetLOLMEquipmentWithExt(version string) ([]*model.LOLMEquipment, error)
 	GetRoadmap(version string, id string) (map[string][]*model.LOLMEquipment, error)
+	GetRoadmapEquips(version string) ([]*model.LOLMEquipment, error)
 }
 
 type LOLMEquipmentDAO struct {
@@ -302,6 +319,18 @@ func (dao *LOLMEquipmentDAO) GetRoadmap(version string, id string, maps []string
 	return result, nil
 }
 
+// GetRoadmapEquips 获取版本下所有装备，用于在内存中构建完整的合成树
+func (dao *LOLMEquipmentDAO) GetRoadmapEquips(version string) ([]*model.LOLMEquipment, error) {
+	cond := map[string]interface{}{
+		"status":  0,
+		"version": version,
+	}
+
+	var equip []*model.LOLMEquipment
+	tx := dao.db.Model(model.LOLMEquipment{}).Where(cond).Order("id asc").Find(&equip)
+	return equip, tx.Error
+}
+
 func (dao *LOLMEquipmentDAO) GetLOLMEquipmentWithExt(version string) ([]*model.LOLMEquipment, error) {
 	notin := strings.Join(config.EquipDict.Exclude, ",")
 
@@ -318,6 +347,9 @@ SELECT
 	equip.from,
 	equip.into,
 	equip.type,
+	equip.level,
+	equip.tags,
+	equip.composeLevel,
 	equip.version,
 	equip.fileTime
 FROM
@@ -43,6 +43,16 @@ func (dao *ESEquipmentDAO) CreateIndex(ctx *context.Context) error {
 		if !createIndex.Acknowledged {
 			return errors.New(fmt.Sprintf("expected IndicesCreateResult.Acknowledged %v; got %v", true, createIndex.Acknowledged))
 		}
+		return nil
+	}
+
+	// 已存在的索引补上分面字段，字段已被动态映射为text时无法修改，需要rebuild
+	putMapping, err := es.ESClient.PutMapping().Index(idxName).BodyString(esModel.GetFacetMapping()).Do(ctx)
+	if err != nil {
+		return errors.New(fmt.Sprintf("put %s facet mapping failed, rebuild the index: %v", idxName, err))
+	}
+	if !putMapping.Acknowledged {
+		return errors.New(fmt.Sprintf("expected PutMappingResponse.Acknowledged %v; got %v", true, putMapping.Acknowledged))
 	}
 	return nil
 }
@@ -0,0 +1,85 @@
+package dao
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroRotationDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroRotationDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroRotation) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroRotation{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	if len(addData) > 0 {
+		tx.Create(addData)
+		if tx.Error != nil {
+			tx.Rollback()
+			return tx.Error
+		}
+	}
+	tx.Commit()
+
+	return nil
+}
+
+// Find 查询 [start, end] 周范围内的免费英雄，按周倒序
+func (dao *HeroRotationDAO) Find(cond map[string]interface{}, start, end string) ([]*model.HeroRotation, error) {
+	var result []*model.HeroRotation
+	tx := dao.db.Model(&model.HeroRotation{}).
+		Where(cond).
+		Where("week >= ? AND week <= ?", start, end).
+		Order("week desc, id asc").
+		Find(&result)
+	return result, tx.Error
+}
+
+// LatestWeek 最近一次有记录的周
+func (dao *HeroRotationDAO) LatestWeek(cond map[string]interface{}) (string, error) {
+	var result model.HeroRotation
+	tx := dao.db.Model(&model.HeroRotation{}).Select("week").Where(cond).Order("week desc").First(&result)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return result.Week, tx.Error
+}
+
+// FindWeeks 英雄免费过的周，按周倒序
+func (dao *HeroRotationDAO) FindWeeks(cond map[string]interface{}) ([]string, error) {
+	var result []string
+	tx := dao.db.Model(&model.HeroRotation{}).
+		Where(cond).
+		Distinct("week").
+		Order("week desc").
+		Pluck("week", &result)
+	return result, tx.Error
+}
+
+var (
+	hrotDao  *HeroRotationDAO
+	hrotOnce sync.Once
+)
+
+func NewHeroRotationDAO() *HeroRotationDAO {
+	hrotOnce.Do(func() {
+		hrotDao = &HeroRotationDAO{
+			db: mysql.DB,
+		}
+	})
+	return hrotDao
+}
+
+type HeroRotation interface {
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroRotation) error
+	Find(cond map[string]interface{}, start, end string) ([]*model.HeroRotation, error)
+	LatestWeek(cond map[string]interface{}) (string, error)
+	FindWeeks(cond map[string]interface{}) ([]string, error)
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroesMatchupDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesMatchupDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesMatchup) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesMatchup{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func (dao *HeroesMatchupDAO) Add(hm []*model.HeroesMatchup) (int64, error) {
+	result := dao.db.Create(hm)
+	return result.RowsAffected, result.Error
+}
+
+func (dao *HeroesMatchupDAO) Delete(cond map[string]interface{}) (int64, error) {
+	tx := dao.db.Delete(&model.HeroesMatchup{}, cond)
+	return tx.RowsAffected, tx.Error
+}
+
+// FindMatchup 查询对位数据，样本数小于minGames的不返回，按胜率排序
+func (dao *HeroesMatchupDAO) FindMatchup(cond map[string]interface{}, minGames int, desc bool, limit int) ([]*model.HeroesMatchup, error) {
+	order := "winrate asc"
+	if desc {
+		order = "winrate desc"
+	}
+	var result []*model.HeroesMatchup
+	tx := dao.db.Model(&model.HeroesMatchup{}).Where(cond).Where("igamecnt >= ?", minGames).Order(order)
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	tx = tx.Find(&result)
+	return result, tx.Error
+}
+
+func (dao *HeroesMatchupDAO) GetMaxVersion(platform int) (*model.HeroesMatchup, error) {
+	var result model.HeroesMatchup
+	tx := dao.db.Model(&model.HeroesMatchup{}).Where("platform = ?", platform).Order("version desc").First(&result)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &result, tx.Error
+}
+
+var (
+	hmDao  *HeroesMatchupDAO
+	hmOnce sync.Once
+)
+
+func NewHeroesMatchupDAO() *HeroesMatchupDAO {
+	hmOnce.Do(func() {
+		hmDao = &HeroesMatchupDAO{
+			db: mysql.DB,
+		}
+	})
+	return hmDao
+}
+
+type HeroesMatchup interface {
+	Add([]*model.HeroesMatchup) (int64, error)
+	Delete(cond map[string]interface{}) (int64, error)
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesMatchup) error
+	FindMatchup(cond map[string]interface{}, minGames int, desc bool, limit int) ([]*model.HeroesMatchup, error)
+	GetMaxVersion(platform int) (*model.HeroesMatchup, error)
+}
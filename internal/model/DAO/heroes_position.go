@@ -1,6 +1,7 @@
 package dao
 
 import (
+	"errors"
 	"gorm.io/gorm"
 	"sync"
 	"whisper/internal/model"
@@ -37,6 +38,21 @@ func (dao *HeroesPositionDAO) Delete(cond map[string]interface{}) (int64, error)
 	return tx.RowsAffected, tx.Error
 }
 
+func (dao *HeroesPositionDAO) Find(cond map[string]interface{}) ([]*model.HeroesPosition, error) {
+	var result []*model.HeroesPosition
+	tx := dao.db.Model(&model.HeroesPosition{}).Where(cond).Find(&result)
+	return result, tx.Error
+}
+
+func (dao *HeroesPositionDAO) GetMaxVersion(platform int) (*model.HeroesPosition, error) {
+	var result model.HeroesPosition
+	tx := dao.db.Model(&model.HeroesPosition{}).Where("platform = ?", platform).Order("version desc").First(&result)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &result, tx.Error
+}
+
 var (
 	hpDao  *HeroesPositionDAO
 	hpOnce sync.Once
@@ -55,4 +71,6 @@ type HeroesPosition interface {
 	Add([]*model.HeroesPosition) (int64, error)
 	Delete(cond map[string]interface{}) (int64, error)
 	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesPosition) error
+	Find(cond map[string]interface{}) ([]*model.HeroesPosition, error)
+	GetMaxVersion(platform int) (*model.HeroesPosition, error)
 }
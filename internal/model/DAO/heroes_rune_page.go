@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroesRunePageDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesRunePageDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesRunePage) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesRunePage{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func (dao *HeroesRunePageDAO) Add(hs []*model.HeroesRunePage) (int64, error) {
+	result := dao.db.Create(hs)
+	return result.RowsAffected, result.Error
+}
+
+func (dao *HeroesRunePageDAO) Delete(cond map[string]interface{}) (int64, error) {
+	tx := dao.db.Delete(&model.HeroesRunePage{}, cond)
+	return tx.RowsAffected, tx.Error
+}
+
+func (dao *HeroesRunePageDAO) Find(cond map[string]interface{}) ([]*model.HeroesRunePage, error) {
+	var result []*model.HeroesRunePage
+	tx := dao.db.Model(&model.HeroesRunePage{}).Where(cond).Order("version desc, winrate desc").Find(&result)
+	return result, tx.Error
+}
+
+var (
+	hrpDao  *HeroesRunePageDAO
+	hrpOnce sync.Once
+)
+
+func NewHeroesRunePageDAO() *HeroesRunePageDAO {
+	hrpOnce.Do(func() {
+		hrpDao = &HeroesRunePageDAO{
+			db: mysql.DB,
+		}
+	})
+	return hrpDao
+}
+
+type HeroesRunePage interface {
+	Add([]*model.HeroesRunePage) (int64, error)
+	Delete(cond map[string]interface{}) (int64, error)
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesRunePage) error
+	Find(cond map[string]interface{}) ([]*model.HeroesRunePage, error)
+}
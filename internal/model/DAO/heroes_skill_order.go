@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroesSkillOrderDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesSkillOrderDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesSkillOrder) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesSkillOrder{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func (dao *HeroesSkillOrderDAO) Add(hs []*model.HeroesSkillOrder) (int64, error) {
+	result := dao.db.Create(hs)
+	return result.RowsAffected, result.Error
+}
+
+func (dao *HeroesSkillOrderDAO) Delete(cond map[string]interface{}) (int64, error) {
+	tx := dao.db.Delete(&model.HeroesSkillOrder{}, cond)
+	return tx.RowsAffected, tx.Error
+}
+
+func (dao *HeroesSkillOrderDAO) Find(cond map[string]interface{}) ([]*model.HeroesSkillOrder, error) {
+	var result []*model.HeroesSkillOrder
+	tx := dao.db.Model(&model.HeroesSkillOrder{}).Where(cond).Order("version desc, winrate desc").Find(&result)
+	return result, tx.Error
+}
+
+var (
+	hsoDao  *HeroesSkillOrderDAO
+	hsoOnce sync.Once
+)
+
+func NewHeroesSkillOrderDAO() *HeroesSkillOrderDAO {
+	hsoOnce.Do(func() {
+		hsoDao = &HeroesSkillOrderDAO{
+			db: mysql.DB,
+		}
+	})
+	return hsoDao
+}
+
+type HeroesSkillOrder interface {
+	Add([]*model.HeroesSkillOrder) (int64, error)
+	Delete(cond map[string]interface{}) (int64, error)
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesSkillOrder) error
+	Find(cond map[string]interface{}) ([]*model.HeroesSkillOrder, error)
+}
@@ -0,0 +1,125 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroesPositionSnapshotDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesPositionSnapshotDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesPositionSnapshot) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesPositionSnapshot{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+// Find 查询 [start, end] 日期范围内的快照，按日期排序
+func (dao *HeroesPositionSnapshotDAO) Find(cond map[string]interface{}, start, end string) ([]*model.HeroesPositionSnapshot, error) {
+	var result []*model.HeroesPositionSnapshot
+	tx := dao.db.Model(&model.HeroesPositionSnapshot{}).
+		Where(cond).
+		Where("statDate >= ? AND statDate <= ?", start, end).
+		Order("statDate asc").
+		Find(&result)
+	return result, tx.Error
+}
+
+// FindDates [start, end] 日期范围内有快照的日期
+func (dao *HeroesPositionSnapshotDAO) FindDates(cond map[string]interface{}, start, end string) ([]string, error) {
+	var result []string
+	tx := dao.db.Model(&model.HeroesPositionSnapshot{}).
+		Where(cond).
+		Where("statDate >= ? AND statDate <= ?", start, end).
+		Distinct("statDate").
+		Order("statDate asc").
+		Pluck("statDate", &result)
+	return result, tx.Error
+}
+
+var (
+	hpsDao  *HeroesPositionSnapshotDAO
+	hpsOnce sync.Once
+)
+
+func NewHeroesPositionSnapshotDAO() *HeroesPositionSnapshotDAO {
+	hpsOnce.Do(func() {
+		hpsDao = &HeroesPositionSnapshotDAO{
+			db: mysql.DB,
+		}
+	})
+	return hpsDao
+}
+
+type HeroesPositionSnapshot interface {
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesPositionSnapshot) error
+	Find(cond map[string]interface{}, start, end string) ([]*model.HeroesPositionSnapshot, error)
+	FindDates(cond map[string]interface{}, start, end string) ([]string, error)
+}
+
+// ---------------------------------------
+
+type HeroesSuitSnapshotDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesSuitSnapshotDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesSuitSnapshot) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesSuitSnapshot{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+// Find 查询 [start, end] 日期范围内的快照，按日期排序
+func (dao *HeroesSuitSnapshotDAO) Find(cond map[string]interface{}, start, end string) ([]*model.HeroesSuitSnapshot, error) {
+	var result []*model.HeroesSuitSnapshot
+	tx := dao.db.Model(&model.HeroesSuitSnapshot{}).
+		Where(cond).
+		Where("statDate >= ? AND statDate <= ?", start, end).
+		Order("statDate asc").
+		Find(&result)
+	return result, tx.Error
+}
+
+var (
+	hssDao  *HeroesSuitSnapshotDAO
+	hssOnce sync.Once
+)
+
+func NewHeroesSuitSnapshotDAO() *HeroesSuitSnapshotDAO {
+	hssOnce.Do(func() {
+		hssDao = &HeroesSuitSnapshotDAO{
+			db: mysql.DB,
+		}
+	})
+	return hssDao
+}
+
+type HeroesSuitSnapshot interface {
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesSuitSnapshot) error
+	Find(cond map[string]interface{}, start, end string) ([]*model.HeroesSuitSnapshot, error)
+}
@@ -53,6 +53,7 @@ SELECT
 	suit.winrate,
 	suit.allcnt,
 	suit.showrate,
+	suit.score,
 	suit.desc,
 	suit.type,
 	suit.platform
@@ -63,10 +64,10 @@ WHERE
 	suit.heroId = %s
 	%s
 ORDER BY
-	winrate desc
+	score desc, winrate desc
 `
 
-	rightJoin := "RIGHT JOIN heroes_position pos ON suit.pos = pos.pos AND suit.heroId = pos.heroId"
+	rightJoin := "RIGHT JOIN heroes_position pos ON suit.pos = pos.pos AND suit.heroId = pos.heroId AND pos.level = 0"
 	if platform == 0 {
 		rate := "and suit.winrate >= 4000 and suit.showrate >= 1000"
 		sql = fmt.Sprintf(sql, rightJoin, heroID, rate)
@@ -101,10 +102,11 @@ func (dao *HeroesSuitDAO) FindHighRateEquip(query []string, cond map[string]inte
 		tx = tx.Select(query)
 	}
 	var result []*model.HeroesSuit
+	// score 为0的是加字段前写入、还没有重新同步的数据，用胜率代替
 	tx = tx.
 		Where(cond).
 		Where(
-			"type in ? AND CAST(winrate AS SIGNED) >= ? AND CAST(showrate AS SIGNED) >= ? AND platform = ?",
+			"type in ? AND IF(score = 0, CAST(winrate AS SIGNED), score) >= ? AND CAST(showrate AS SIGNED) >= ? AND platform = ?",
 			[]int{0, 1, 2, 3}, 4000, 4000, 0).
 		Or("winrate = ? AND showrate= ? AND platform = ?", "", "", 1).
 		Find(&result)
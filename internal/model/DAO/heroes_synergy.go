@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroesSynergyDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesSynergyDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesSynergy) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesSynergy{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func (dao *HeroesSynergyDAO) Add(hs []*model.HeroesSynergy) (int64, error) {
+	result := dao.db.Create(hs)
+	return result.RowsAffected, result.Error
+}
+
+func (dao *HeroesSynergyDAO) Delete(cond map[string]interface{}) (int64, error) {
+	tx := dao.db.Delete(&model.HeroesSynergy{}, cond)
+	return tx.RowsAffected, tx.Error
+}
+
+func (dao *HeroesSynergyDAO) Find(cond map[string]interface{}) ([]*model.HeroesSynergy, error) {
+	var result []*model.HeroesSynergy
+	tx := dao.db.Model(&model.HeroesSynergy{}).Where(cond).Order("winrate desc").Find(&result)
+	return result, tx.Error
+}
+
+func (dao *HeroesSynergyDAO) GetMaxVersion(platform int) (*model.HeroesSynergy, error) {
+	var result model.HeroesSynergy
+	tx := dao.db.Model(&model.HeroesSynergy{}).Where("platform = ?", platform).Order("version desc").First(&result)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &result, tx.Error
+}
+
+var (
+	hsyDao  *HeroesSynergyDAO
+	hsyOnce sync.Once
+)
+
+func NewHeroesSynergyDAO() *HeroesSynergyDAO {
+	hsyOnce.Do(func() {
+		hsyDao = &HeroesSynergyDAO{
+			db: mysql.DB,
+		}
+	})
+	return hsyDao
+}
+
+type HeroesSynergy interface {
+	Add([]*model.HeroesSynergy) (int64, error)
+	Delete(cond map[string]interface{}) (int64, error)
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesSynergy) error
+	Find(cond map[string]interface{}) ([]*model.HeroesSynergy, error)
+	GetMaxVersion(platform int) (*model.HeroesSynergy, error)
+}
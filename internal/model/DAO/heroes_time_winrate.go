@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type HeroesTimeWinrateDAO struct {
+	db *gorm.DB
+}
+
+func (dao *HeroesTimeWinrateDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesTimeWinrate) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.HeroesTimeWinrate{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Create(addData)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func (dao *HeroesTimeWinrateDAO) Add(hs []*model.HeroesTimeWinrate) (int64, error) {
+	result := dao.db.Create(hs)
+	return result.RowsAffected, result.Error
+}
+
+func (dao *HeroesTimeWinrateDAO) Delete(cond map[string]interface{}) (int64, error) {
+	tx := dao.db.Delete(&model.HeroesTimeWinrate{}, cond)
+	return tx.RowsAffected, tx.Error
+}
+
+func (dao *HeroesTimeWinrateDAO) Find(cond map[string]interface{}) ([]*model.HeroesTimeWinrate, error) {
+	var result []*model.HeroesTimeWinrate
+	tx := dao.db.Model(&model.HeroesTimeWinrate{}).Where(cond).Order("version desc, pos, startMinute").Find(&result)
+	return result, tx.Error
+}
+
+var (
+	htwDao  *HeroesTimeWinrateDAO
+	htwOnce sync.Once
+)
+
+func NewHeroesTimeWinrateDAO() *HeroesTimeWinrateDAO {
+	htwOnce.Do(func() {
+		htwDao = &HeroesTimeWinrateDAO{
+			db: mysql.DB,
+		}
+	})
+	return htwDao
+}
+
+type HeroesTimeWinrate interface {
+	Add([]*model.HeroesTimeWinrate) (int64, error)
+	Delete(cond map[string]interface{}) (int64, error)
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.HeroesTimeWinrate) error
+	Find(cond map[string]interface{}) ([]*model.HeroesTimeWinrate, error)
+}
@@ -13,6 +13,7 @@ import (
 type MongoEquipment interface {
 	Add(ctx *context.Context, ei []*model.EquipIntro) error
 	Find(ctx *context.Context, cond map[string]interface{}) ([]*model.EquipIntro, error)
+	FindWithSort(ctx *context.Context, filter bson.M, sort bson.D) ([]*model.EquipIntro, error)
 	Delete(ctx *context.Context, cond map[string]interface{}) error
 }
 
@@ -36,6 +37,20 @@ func (d *MongoEquipmentDAO) Find(ctx *context.Context, filter bson.M) ([]*model.
 
 	return results, nil
 }
+func (d *MongoEquipmentDAO) FindWithSort(ctx *context.Context, filter bson.M, sort bson.D) ([]*model.EquipIntro, error) {
+	opts := options.Find().SetSort(sort)
+	cursor, err := d.db.Collection(d.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []*model.EquipIntro
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (d *MongoEquipmentDAO) Add(ctx *context.Context, ei []*model.EquipIntro) error {
 	// 创建要插入的文档
 	data := make([]interface{}, 0, len(ei))
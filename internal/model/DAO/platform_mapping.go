@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"sync"
+	"whisper/internal/model"
+	"whisper/pkg/mysql"
+)
+
+type PlatformMappingDAO struct {
+	db *gorm.DB
+}
+
+func (dao *PlatformMappingDAO) DeleteAndInsert(delCond map[string]interface{}, addData []*model.PlatformMapping) error {
+	tx := dao.db.Begin()
+	tx.Delete(&model.PlatformMapping{}, delCond)
+	if tx.Error != nil {
+		tx.Rollback()
+		return tx.Error
+	}
+	if len(addData) > 0 {
+		tx.Create(addData)
+		if tx.Error != nil {
+			tx.Rollback()
+			return tx.Error
+		}
+	}
+	tx.Commit()
+
+	return nil
+}
+
+// Override 删除与两边ID有关的对应关系后写入手动指定的
+func (dao *PlatformMappingDAO) Override(data *model.PlatformMapping) error {
+	tx := dao.db.Begin()
+	del := tx.Where("type = ?", data.Type)
+	if data.LolmId != "" {
+		del = del.Where("lolId = ? OR lolmId = ?", data.LolId, data.LolmId)
+	} else {
+		del = del.Where("lolId = ?", data.LolId)
+	}
+	if err := del.Delete(&model.PlatformMapping{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(data).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	return nil
+}
+
+func (dao *PlatformMappingDAO) Find(cond map[string]interface{}) ([]*model.PlatformMapping, error) {
+	var result []*model.PlatformMapping
+	tx := dao.db.Model(&model.PlatformMapping{}).Where(cond).Order("source desc, similarity desc").Find(&result)
+	return result, tx.Error
+}
+
+var (
+	pmDao  *PlatformMappingDAO
+	pmOnce sync.Once
+)
+
+func NewPlatformMappingDAO() *PlatformMappingDAO {
+	pmOnce.Do(func() {
+		pmDao = &PlatformMappingDAO{
+			db: mysql.DB,
+		}
+	})
+	return pmDao
+}
+
+type PlatformMapping interface {
+	DeleteAndInsert(delCond map[string]interface{}, addData []*model.PlatformMapping) error
+	Override(data *model.PlatformMapping) error
+	Find(cond map[string]interface{}) ([]*model.PlatformMapping, error)
+}
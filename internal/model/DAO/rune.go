@@ -129,6 +129,10 @@ SELECT
 	r.detailInfo,
 	r.iconPath,
 	r.type,
+	r.sortOrder,
+	r.unlockLv,
+	r.primarySlotIndex,
+	r.primarySlotSortOrder,
 	rt.name as styleName,
 	r.fileTime,
 	r.version
@@ -20,6 +20,8 @@ type ESEquipment struct {
 	From         string `json:"from,omitempty"`
 	Into         string `json:"into,omitempty"`
 	Types        string `json:"types,omitempty"`
+	Tier         string `json:"tier,omitempty"`         // 装备等级，见 logic.EquipTierXXX
+	ComposeLevel string `json:"composeLevel,omitempty"` // 合成等级，仅LOLM
 	Version      string `json:"version,omitempty"`
 	FileTime     string `json:"fileTime,omitempty"`
 	Platform     string `json:"platform"`
@@ -85,6 +87,12 @@ func (e *ESEquipment) GetMapping() string {
             },
             "version": {
                 "type": "keyword"
+            },
+            "tier": {
+                "type": "keyword"
+            },
+            "composeLevel": {
+                "type": "keyword"
             }
         }
     }
@@ -92,6 +100,22 @@ func (e *ESEquipment) GetMapping() string {
 `
 }
 
+// GetFacetMapping 分面字段的mapping，已存在的索引通过put mapping补上
+func (e *ESEquipment) GetFacetMapping() string {
+	return `
+{
+    "properties": {
+        "tier": {
+            "type": "keyword"
+        },
+        "composeLevel": {
+            "type": "keyword"
+        }
+    }
+}
+`
+}
+
 func (e *ESEquipment) GetIndexName() string {
 	return "lol_equipment"
 }
@@ -11,6 +11,7 @@ type ESSkill struct {
 	IconPath    string `json:"iconPath,omitempty"`
 	Maps        string `json:"maps,omitempty"`
 	CoolDown    string `json:"cooldown,omitempty"`
+	UnlockLv    string `json:"unlockLv,omitempty"`
 	Version     string `json:"version,omitempty"`
 	FileTime    string `json:"fileTime,omitempty"`
 	Platform    string `json:"platform"`
@@ -59,6 +60,9 @@ func (e *ESSkill) GetMapping() string {
             "cooldown": {
                 "type": "keyword"
             },
+            "unlockLv": {
+                "type": "keyword"
+            },
             "fileTime": {
                 "type": "keyword"
             },
@@ -0,0 +1,95 @@
+package model
+
+import "sync"
+
+type ESSkin struct {
+	ID          string `json:"id"`
+	HeroId      string `json:"heroId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	HeroName    string `json:"heroName,omitempty"`
+	Keywords    string `json:"keywords,omitempty"`
+	Description string `json:"description,omitempty"`
+	SuitType    string `json:"suitType,omitempty"`
+	EmblemsName string `json:"emblemsName,omitempty"`
+	IconPath    string `json:"iconPath,omitempty"`
+	MainImg     string `json:"mainImg,omitempty"`
+	PublishTime string `json:"publishTime,omitempty"`
+	Chromas     int    `json:"chromas"`
+	Version     string `json:"version,omitempty"`
+	FileTime    string `json:"fileTime,omitempty"`
+	Platform    string `json:"platform"`
+}
+
+var (
+	modelSkin *ESSkin
+	onceSkin  sync.Once
+)
+
+func NewModelESSkin() *ESSkin {
+	onceSkin.Do(func() {
+		modelSkin = new(ESSkin)
+	})
+
+	return modelSkin
+}
+
+func (e *ESSkin) GetMapping() string {
+	return `
+{
+    "mappings": {
+        "properties": {
+            "name": {
+                "type": "text",
+                "analyzer": "ik_smart"
+            },
+            "heroName": {
+                "type": "text",
+                "analyzer": "ik_smart"
+            },
+            "keywords": {
+                "type": "text",
+                "analyzer": "ik_smart"
+            },
+            "description": {
+                "type": "text",
+                "analyzer": "ik_smart"
+            },
+            "suitType": {
+                "type": "keyword"
+            },
+            "emblemsName": {
+                "type": "keyword"
+            },
+            "heroId": {
+                "type": "keyword"
+            },
+            "iconPath": {
+                "type": "keyword"
+            },
+            "mainImg": {
+                "type": "keyword"
+            },
+            "publishTime": {
+                "type": "keyword"
+            },
+            "chromas": {
+                "type": "integer"
+            },
+            "fileTime": {
+                "type": "keyword"
+            },
+            "platform": {
+                "type": "keyword"
+            },
+            "version": {
+                "type": "keyword"
+            }
+        }
+    }
+}
+`
+}
+
+func (e *ESSkin) GetIndexName() string {
+	return "lol_skin"
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroRotation 每周的免费英雄，week 为该周周一的日期
+type HeroRotation struct {
+	Id       uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId   string    `gorm:"column:heroId;default:;NOT NULL"`
+	Type     string    `gorm:"column:type;default:;NOT NULL;comment:'week/aram/permanent'"`
+	Week     string    `gorm:"column:week;default:;NOT NULL;comment:'上游文件时间所在周的周一 2006-01-02'"`
+	Platform int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version  string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime    time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime    time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroRotation) TableName() string {
+	return "hero_rotation"
+}
+
+// TypeWeek 周免
+func (h *HeroRotation) TypeWeek() string {
+	return "week"
+}
+
+// TypeARAM 极地大乱斗周免，只有LOL有
+func (h *HeroRotation) TypeARAM() string {
+	return "aram"
+}
+
+// TypePermanent 新手永久免费，只有LOL有
+func (h *HeroRotation) TypePermanent() string {
+	return "permanent"
+}
@@ -5,25 +5,30 @@ import (
 )
 
 type HeroSkin struct {
-	Id          uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
-	HeroId      string    `gorm:"column:heroId;default:;NOT NULL"`
-	SkinId      string    `gorm:"column:skinId;default:;NOT NULL"`
-	HeroName    string    `gorm:"column:heroName;default:;NOT NULL"`
-	HeroTitle   string    `gorm:"column:heroTitle;default:;NOT NULL"`
-	Name        string    `gorm:"column:name;default:;NOT NULL"`
-	IsBase      string    `gorm:"column:isBase;default:;NOT NULL"`
-	EmblemsName string    `gorm:"column:emblemsName;default:;NOT NULL"`
-	Description string    `gorm:"column:description;default:;NOT NULL"`
-	MainImg     string    `gorm:"column:mainImg;default:;NOT NULL"`
-	IconImg     string    `gorm:"column:iconImg;default:;NOT NULL"`
-	LoadingImg  string    `gorm:"column:loadingImg;default:;NOT NULL"`
-	VideoImg    string    `gorm:"column:videoImg;default:;NOT NULL"`
-	SourceImg   string    `gorm:"column:sourceImg;default:;NOT NULL"`
-	Platform    int       `gorm:"column:platform;default:0;NOT NULL"`
-	Version     string    `gorm:"column:version;default:;NOT NULL"`
-	FileTime    string    `gorm:"column:fileTime;default:;NOT NULL"`
-	Ctime       time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
-	Utime       time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+	Id              uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId          string    `gorm:"column:heroId;default:;NOT NULL"`
+	SkinId          string    `gorm:"column:skinId;default:;NOT NULL"`
+	HeroName        string    `gorm:"column:heroName;default:;NOT NULL"`
+	HeroTitle       string    `gorm:"column:heroTitle;default:;NOT NULL"`
+	Name            string    `gorm:"column:name;default:;NOT NULL"`
+	IsBase          string    `gorm:"column:isBase;default:;NOT NULL"`
+	EmblemsName     string    `gorm:"column:emblemsName;default:;NOT NULL"`
+	Description     string    `gorm:"column:description;default:;NOT NULL"`
+	MainImg         string    `gorm:"column:mainImg;default:;NOT NULL"`
+	IconImg         string    `gorm:"column:iconImg;default:;NOT NULL"`
+	LoadingImg      string    `gorm:"column:loadingImg;default:;NOT NULL"`
+	VideoImg        string    `gorm:"column:videoImg;default:;NOT NULL"`
+	SourceImg       string    `gorm:"column:sourceImg;default:;NOT NULL"`
+	ChromaImg       string    `gorm:"column:chromaImg;default:;NOT NULL"`
+	Chromas         string    `gorm:"column:chromas;default:;NOT NULL;comment:'1:有炫彩'"`
+	ChromasBelongId string    `gorm:"column:chromasBelongId;default:;NOT NULL;comment:'炫彩所属的皮肤ID，不是炫彩时为0'"`
+	SuitType        string    `gorm:"column:suitType;default:;NOT NULL;comment:'皮肤系列'"`
+	PublishTime     string    `gorm:"column:publishTime;default:;NOT NULL"`
+	Platform        int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version         string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime        string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime           time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime           time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
 }
 
 func (h *HeroSkin) TableName() string {
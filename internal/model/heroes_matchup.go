@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroesMatchup 英雄在某个位置上与对手的对位数据，胜率为万分比
+type HeroesMatchup struct {
+	Id         uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId     string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos        string    `gorm:"column:pos;default:;NOT NULL"`
+	OpponentId string    `gorm:"column:opponentId;default:;NOT NULL"`
+	Igamecnt   int32     `gorm:"column:igamecnt;default:0;NOT NULL"`
+	Wincnt     int32     `gorm:"column:wincnt;default:0;NOT NULL"`
+	Winrate    int32     `gorm:"column:winrate;default:0;NOT NULL"`
+	Platform   int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version    string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime   string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime      time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime      time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesMatchup) TableName() string {
+	return "heroes_matchup"
+}
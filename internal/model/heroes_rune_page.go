@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroesRunePage 英雄推荐的完整符文页，胜率、出场率为万分比
+type HeroesRunePage struct {
+	Id             uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId         string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos            string    `gorm:"column:pos;default:;NOT NULL"`
+	PrimaryStyle   string    `gorm:"column:primaryStyle;default:;NOT NULL;comment:'主系'"`
+	Keystone       string    `gorm:"column:keystone;default:;NOT NULL;comment:'基石符文'"`
+	PrimaryRunes   string    `gorm:"column:primaryRunes;default:;NOT NULL;comment:'主系其余符文'"`
+	SecondaryStyle string    `gorm:"column:secondaryStyle;default:;NOT NULL;comment:'副系'"`
+	SecondaryRunes string    `gorm:"column:secondaryRunes;default:;NOT NULL"`
+	Shards         string    `gorm:"column:shards;default:;NOT NULL;comment:'属性碎片'"`
+	Runeids        string    `gorm:"column:runeids;default:;NOT NULL"`
+	Igamecnt       int32     `gorm:"column:igamecnt;default:0;NOT NULL"`
+	Wincnt         int32     `gorm:"column:wincnt;default:0;NOT NULL"`
+	Winrate        int32     `gorm:"column:winrate;default:0;NOT NULL"`
+	Showrate       int32     `gorm:"column:showrate;default:0;NOT NULL"`
+	Platform       int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version        string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime       string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime          time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime          time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesRunePage) TableName() string {
+	return "heroes_rune_page"
+}
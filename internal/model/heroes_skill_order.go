@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroesSkillOrder 英雄技能加点推荐，胜率、出场率为万分比
+type HeroesSkillOrder struct {
+	Id         uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId     string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos        string    `gorm:"column:pos;default:;NOT NULL"`
+	SkillOrder string    `gorm:"column:skillOrder;default:;NOT NULL;comment:'主升顺序，如Q,E,W'"`
+	Sequence   string    `gorm:"column:sequence;default:;NOT NULL;comment:'逐级加点，如Q,W,E,Q,Q,R'"`
+	Igamecnt   int32     `gorm:"column:igamecnt;default:0;NOT NULL"`
+	Wincnt     int32     `gorm:"column:wincnt;default:0;NOT NULL"`
+	Winrate    int32     `gorm:"column:winrate;default:0;NOT NULL"`
+	Showrate   int32     `gorm:"column:showrate;default:0;NOT NULL"`
+	Platform   int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version    string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime   string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime      time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime      time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesSkillOrder) TableName() string {
+	return "heroes_skill_order"
+}
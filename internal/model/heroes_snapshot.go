@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroesPositionSnapshot heroes_position 的每日快照
+type HeroesPositionSnapshot struct {
+	Id            uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId        string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos           string    `gorm:"column:pos;default:;NOT NULL"`
+	ShowRate      int       `gorm:"column:show_rate;default:0;NOT NULL"`
+	WinRate       int       `gorm:"column:win_rate;default:0;NOT NULL"`
+	BanRate       int       `gorm:"column:ban_rate;default:0;NOT NULL"`
+	Level         int       `gorm:"column:level;default:0;NOT NULL"`
+	Strength      string    `gorm:"column:strength;default:;NOT NULL"`
+	StrengthLevel string    `gorm:"column:strength_level;default:;NOT NULL"`
+	Platform      int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version       string    `gorm:"column:version;default:;NOT NULL"`
+	StatDate      string    `gorm:"column:statDate;default:;NOT NULL;comment:'统计日期 2006-01-02'"`
+	Ctime         time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime         time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesPositionSnapshot) TableName() string {
+	return "heroes_position_snapshot"
+}
+
+// --------------------------------
+
+// HeroesSuitSnapshot heroes_suit 的每日快照，只有LOL有统计数据，LOLM只记录推荐的装备、符文和召唤师技能
+type HeroesSuitSnapshot struct {
+	Id       uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId   string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos      string    `gorm:"column:pos;default:;NOT NULL"`
+	Itemids  string    `gorm:"column:itemids;default:;NOT NULL"`
+	Runeids  string    `gorm:"column:runeids;default:;NOT NULL"`
+	Skillids string    `gorm:"column:skillids;default:;NOT NULL"`
+	Igamecnt int32     `gorm:"column:igamecnt;default:0;NOT NULL"`
+	Wincnt   int32     `gorm:"column:wincnt;default:0;NOT NULL"`
+	Winrate  int32     `gorm:"column:winrate;default:0;NOT NULL"`
+	Allcnt   int32     `gorm:"column:allcnt;default:0;NOT NULL"`
+	Showrate int32     `gorm:"column:showrate;default:0;NOT NULL"`
+	Type     int32     `gorm:"column:type;default:0;NOT NULL"`
+	Platform int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version  string    `gorm:"column:version;default:;NOT NULL"`
+	StatDate string    `gorm:"column:statDate;default:;NOT NULL"`
+	Ctime    time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime    time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesSuitSnapshot) TableName() string {
+	return "heroes_suit_snapshot"
+}
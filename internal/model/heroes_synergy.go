@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroesSynergy 英雄与队友组合（下路双人、打野与线上）的数据，胜率为万分比
+type HeroesSynergy struct {
+	Id         uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId     string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos        string    `gorm:"column:pos;default:;NOT NULL"`
+	PartnerId  string    `gorm:"column:partnerId;default:;NOT NULL"`
+	PartnerPos string    `gorm:"column:partnerPos;default:;NOT NULL"`
+	Igamecnt   int32     `gorm:"column:igamecnt;default:0;NOT NULL"`
+	Wincnt     int32     `gorm:"column:wincnt;default:0;NOT NULL"`
+	Winrate    int32     `gorm:"column:winrate;default:0;NOT NULL"`
+	Platform   int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version    string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime   string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime      time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime      time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesSynergy) TableName() string {
+	return "heroes_synergy"
+}
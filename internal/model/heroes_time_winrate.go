@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// HeroesTimeWinrate 英雄在不同对局时长下的胜率，胜率为万分比
+type HeroesTimeWinrate struct {
+	Id          uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId      string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos         string    `gorm:"column:pos;default:;NOT NULL"`
+	TimeSection string    `gorm:"column:timeSection;default:;NOT NULL;comment:'对局时长区间，如 25-30'"`
+	StartMinute int       `gorm:"column:startMinute;default:0;NOT NULL"`
+	Igamecnt    int32     `gorm:"column:igamecnt;default:0;NOT NULL"`
+	Wincnt      int32     `gorm:"column:wincnt;default:0;NOT NULL"`
+	Winrate     int32     `gorm:"column:winrate;default:0;NOT NULL"`
+	Platform    int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version     string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime    string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime       time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime       time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (h *HeroesTimeWinrate) TableName() string {
+	return "heroes_time_winrate"
+}
@@ -5,16 +5,20 @@ import (
 )
 
 type HeroesPosition struct {
-	Id       uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
-	HeroId   string    `gorm:"column:heroId;default:;NOT NULL"`
-	Pos      string    `gorm:"column:pos;default:;NOT NULL"`
-	ShowRate int       `gorm:"column:show_rate;default:0;NOT NULL"`
-	WinRate  int       `gorm:"column:win_rate;default:0;NOT NULL"`
-	Platform int       `gorm:"column:platform;default:0;NOT NULL"`
-	Version  string    `gorm:"column:version;default:;NOT NULL"`
-	FileTime string    `gorm:"column:fileTime;default:;NOT NULL"`
-	Ctime    time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
-	Utime    time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+	Id            uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	HeroId        string    `gorm:"column:heroId;default:;NOT NULL"`
+	Pos           string    `gorm:"column:pos;default:;NOT NULL"`
+	ShowRate      int       `gorm:"column:show_rate;default:0;NOT NULL"`
+	WinRate       int       `gorm:"column:win_rate;default:0;NOT NULL"`
+	BanRate       int       `gorm:"column:ban_rate;default:0;NOT NULL"`
+	Level         int       `gorm:"column:level;default:0;NOT NULL;comment:'段位，见 common.LevelDiamond 等'"`
+	Strength      string    `gorm:"column:strength;default:;NOT NULL;comment:'强度分，仅LOLM'"`
+	StrengthLevel string    `gorm:"column:strength_level;default:;NOT NULL;comment:'强度等级，仅LOLM'"`
+	Platform      int       `gorm:"column:platform;default:0;NOT NULL"`
+	Version       string    `gorm:"column:version;default:;NOT NULL"`
+	FileTime      string    `gorm:"column:fileTime;default:;NOT NULL"`
+	Ctime         time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime         time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
 }
 
 func (h *HeroesPosition) TableName() string {
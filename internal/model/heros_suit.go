@@ -21,6 +21,7 @@ type HeroesSuit struct {
 	Winrate     int32     `gorm:"column:winrate;default:0;NOT NULL"`
 	Allcnt      int32     `gorm:"column:allcnt;default:0;NOT NULL"`
 	Showrate    int32     `gorm:"column:showrate;default:0;NOT NULL"`
+	Score       int32     `gorm:"column:score;default:0;NOT NULL;comment:'胜率的Wilson下界，万分比'"`
 	Type        int32     `gorm:"column:type;default:0;NOT NULL;comment:'0:单件适合 1:鞋子 2:出门装 3:核心三件套'"`
 	Platform    int       `gorm:"column:platform;default:0;NOT NULL"`
 	Version     string    `gorm:"column:version;default:;NOT NULL"`
@@ -11,6 +11,10 @@ type EquipIntro struct {
 	Platform  uint8    `json:"platform"`
 	Version   string   `json:"version"`
 	Keywords  []string `json:"keywords"`
+
+	Tier         string             `json:"tier"`         // basic/epic/legendary，LOLM为 basic/advanced/legendary/enchant
+	ComposeLevel int                `json:"composeLevel"` // 合成等级，仅LOLM
+	Stats        map[string]float64 `json:"stats"`        // 装备属性，key见 logic.StatXXX
 }
 
 func (e *EquipIntro) CollectionName() string {
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+)
+
+// PlatformMapping LOL与LOLM中同一个英雄、装备、符文、召唤师技能的对应关系，相似度为万分比
+type PlatformMapping struct {
+	Id         uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT;NOT NULL"`
+	Type       string    `gorm:"column:type;default:;NOT NULL;comment:'hero/equip/rune/skill'"`
+	LolId      string    `gorm:"column:lolId;default:;NOT NULL"`
+	LolName    string    `gorm:"column:lolName;default:;NOT NULL"`
+	LolmId     string    `gorm:"column:lolmId;default:;NOT NULL;comment:'为空表示手动指定没有对应'"`
+	LolmName   string    `gorm:"column:lolmName;default:;NOT NULL"`
+	Similarity int32     `gorm:"column:similarity;default:0;NOT NULL"`
+	Source     int8      `gorm:"column:source;default:0;NOT NULL;comment:'0:自动匹配 1:手动指定'"`
+	Ctime      time.Time `gorm:"column:ctime;default:current_timestamp();NOT NULL"`
+	Utime      time.Time `gorm:"column:utime;default:current_timestamp();NOT NULL"`
+}
+
+func (p *PlatformMapping) TableName() string {
+	return "platform_mapping"
+}
+
+// SourceAuto 按名称自动匹配
+func (p *PlatformMapping) SourceAuto() int8 {
+	return 0
+}
+
+// SourceManual 手动指定，自动匹配时不会覆盖
+func (p *PlatformMapping) SourceManual() int8 {
+	return 1
+}
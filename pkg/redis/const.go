@@ -18,17 +18,21 @@ const (
 	KeyCacheHeroEquip = "cache:hero_equip"
 
 	// KeyCacheEquipHeroSuit HSET cache:equip_hero:platform:item
-	KeyCacheEquipHeroSuit    = "cache:equip_hero:%d:%s"
-	KeyCacheEquipHeroSuitAll = "cache:equip_hero:*"
+	KeyCacheEquipHeroSuit = "cache:equip_hero:%d:%s"
 	// KeyCacheRuneHeroSuit HSET cache:rune_hero:platform:item
-	KeyCacheRuneHeroSuit    = "cache:rune_hero:%d:%s"
-	KeyCacheRuneHeroSuitAll = "cache:rune_hero:*"
+	KeyCacheRuneHeroSuit = "cache:rune_hero:%d:%s"
 	// KeyCacheSkillHeroSuit HSET cache:skill_hero:platform:item
-	KeyCacheSkillHeroSuit    = "cache:skill_hero:%d:%s"
-	KeyCacheSkillHeroSuitAll = "cache:skill_hero:*"
+	KeyCacheSkillHeroSuit = "cache:skill_hero:%d:%s"
+	// KeyCacheSuitHeroGames HSET cache:suit_hero_games:{上面三个key} 英雄的样本数
+	KeyCacheSuitHeroGames = "cache:suit_hero_games:%s"
 
 	// KeyCacheVersionList SET cache:version:1
 	KeyCacheVersionList = "cache:version:%d"
 	// KeyCacheVersionDetail SET cache:version:detail:4.2_hero
 	KeyCacheVersionDetail = "cache:version:detail:%s"
+
+	// KeyCacheTierList SET cache:tier_list:platform:version:pos:level:formula
+	KeyCacheTierList = "cache:tier_list:%d:%s:%s:%d:%s"
+	// KeyCacheTierListAll 某个平台所有的梯队缓存，英雄位置数据更新后删除
+	KeyCacheTierListAll = "cache:tier_list:%d:*"
 )
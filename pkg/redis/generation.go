@@ -0,0 +1,257 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cast"
+)
+
+// 按代（generation）重建的缓存
+// 每次重建写入新的一代 gen:{namespace}:{id}:{key}，全部写完后再切换当前代的指针，
+// 读取时通过当前代拼出实际的key，切换前读到的始终是上一代的完整数据
+const (
+	// CacheNamespaceSuit 英雄出装（cache:equip、cache:rune、cache:skill、cache:hero_equip）
+	CacheNamespaceSuit = "suit"
+	// CacheNamespaceSuitHero 装备、符文、召唤师技能适配的英雄（cache:equip_hero 等）
+	CacheNamespaceSuitHero = "suit_hero"
+)
+
+// CacheNamespaces 所有按代管理的缓存
+var CacheNamespaces = []string{CacheNamespaceSuit, CacheNamespaceSuitHero}
+
+// legacyKeyPatterns 按代管理之前直接写入的key，第一次切换代时删除
+var legacyKeyPatterns = map[string][]string{
+	CacheNamespaceSuit:     {"cache:equip:*", "cache:rune:*", "cache:skill:*", KeyCacheHeroEquip},
+	CacheNamespaceSuitHero: {"cache:equip_hero:*", "cache:rune_hero:*", "cache:skill_hero:*", "cache:suit_hero_games:*"},
+}
+
+const (
+	// KeyCacheGenSeq STRING cache:gen_seq:namespace 代的自增ID
+	KeyCacheGenSeq = "cache:gen_seq:%s"
+	// KeyCacheGenActive HSET cache:gen_active:namespace 当前代 id、切换时间 activated
+	KeyCacheGenActive = "cache:gen_active:%s"
+	// KeyCacheGenList ZSET cache:gen_list:namespace 所有的代，score为创建时间
+	KeyCacheGenList = "cache:gen_list:%s"
+	// KeyCacheGenStats HSET cache:gen_stats:namespace 命中 hit、未命中 miss 次数
+	KeyCacheGenStats = "cache:gen_stats:%s"
+	// KeyCacheGenPrefix 某一代的key前缀 gen:namespace:id:
+	KeyCacheGenPrefix = "gen:%s:%d:"
+)
+
+// CacheGenerationKeep 除当前代外保留的旧代数量，用于回滚
+const CacheGenerationKeep = 1
+
+type Generation struct {
+	Namespace string
+	ID        int64 // 0 表示还没有切换过代，直接使用原来的key
+}
+
+// Key 该代下实际的key
+func (g *Generation) Key(key string) string {
+	if g.ID == 0 {
+		return key
+	}
+	return fmt.Sprintf(KeyCacheGenPrefix, g.Namespace, g.ID) + key
+}
+
+// NewGeneration 开始重建一代新的缓存
+func NewGeneration(ctx context.Context, namespace string) (*Generation, error) {
+	id, err := RDB.Incr(ctx, fmt.Sprintf(KeyCacheGenSeq, namespace)).Result()
+	if err != nil {
+		return nil, err
+	}
+	err = RDB.ZAdd(ctx, fmt.Sprintf(KeyCacheGenList, namespace), redis.Z{
+		Score:  float64(time.Now().Unix()),
+		Member: id,
+	}).Err()
+	if err != nil {
+		return nil, err
+	}
+	return &Generation{Namespace: namespace, ID: id}, nil
+}
+
+// ActiveGeneration 当前代，读取失败时退回原来的key
+func ActiveGeneration(ctx context.Context, namespace string) *Generation {
+	id, _ := RDB.HGet(ctx, fmt.Sprintf(KeyCacheGenActive, namespace), "id").Int64()
+	return &Generation{Namespace: namespace, ID: id}
+}
+
+// ErrStaleGeneration 已经有更新的一代被切换，较旧的一代不能再切换
+var ErrStaleGeneration = errors.New("a newer generation is already active")
+
+// activateScript 只有比当前代新时才切换，返回切换前的代，不切换时返回-1
+var activateScript = redis.NewScript(`
+local prev = tonumber(redis.call('HGET', KEYS[1], 'id') or '0') or 0
+if tonumber(ARGV[1]) <= prev then
+	return -1
+end
+redis.call('HSET', KEYS[1], 'id', ARGV[1], 'activated', ARGV[2])
+return prev
+`)
+
+// Activate 把当前代切换为g，然后回收旧的代
+// 两次重建重叠时，先开始的一代后写完不能覆盖更新的一代，比较和切换在同一个脚本里原子执行
+// 第一次切换时一并删除切换前直接使用的旧key
+func (g *Generation) Activate(ctx context.Context) (int, error) {
+	if g.ID == 0 {
+		return 0, errors.New("invalid generation")
+	}
+	prev, err := activateScript.Run(ctx, RDB, []string{fmt.Sprintf(KeyCacheGenActive, g.Namespace)}, g.ID, time.Now().Unix()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if prev < 0 {
+		return 0, ErrStaleGeneration
+	}
+
+	deleted := 0
+	if prev == 0 {
+		for _, pattern := range legacyKeyPatterns[g.Namespace] {
+			n, err := DeleteKeys(ctx, pattern)
+			deleted += n
+			if err != nil {
+				return deleted, err
+			}
+		}
+	}
+	n, err := GCGenerations(ctx, g.Namespace, CacheGenerationKeep)
+	return deleted + n, err
+}
+
+// Discard 删除没有切换的一代，重建失败时调用，避免占用保留的旧代
+func (g *Generation) Discard(ctx context.Context) (int, error) {
+	if g.ID == 0 {
+		return 0, errors.New("invalid generation")
+	}
+	deleted, err := deleteGeneration(ctx, g)
+	if err != nil {
+		return deleted, err
+	}
+	return deleted, RDB.ZRem(ctx, fmt.Sprintf(KeyCacheGenList, g.Namespace), g.ID).Err()
+}
+
+// GCGenerations 删除比当前代旧的代，保留最近的 keep 代；比当前代新的可能还在重建中，不删除
+func GCGenerations(ctx context.Context, namespace string, keep int) (int, error) {
+	active := ActiveGeneration(ctx, namespace)
+	if active.ID == 0 {
+		return 0, nil
+	}
+	listKey := fmt.Sprintf(KeyCacheGenList, namespace)
+	ids, err := RDB.ZRevRange(ctx, listKey, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	older := 0
+	for _, s := range ids {
+		id := cast.ToInt64(s)
+		if id >= active.ID {
+			continue
+		}
+		older++
+		if older <= keep {
+			continue
+		}
+		n, err := deleteGeneration(ctx, &Generation{Namespace: namespace, ID: id})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+		RDB.ZRem(ctx, listKey, s)
+	}
+	return deleted, nil
+}
+
+func deleteGeneration(ctx context.Context, g *Generation) (int, error) {
+	return DeleteKeys(ctx, g.Key("*"))
+}
+
+// DeleteKeys 按 pattern 删除key
+func DeleteKeys(ctx context.Context, pattern string) (int, error) {
+	deleted := 0
+	iter := RDB.Scan(ctx, 0, pattern, 1000).Iterator()
+	keys := make([]string, 0, 500)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == cap(keys) {
+			deleted += int(RDB.Unlink(ctx, keys...).Val())
+			keys = keys[:0]
+		}
+	}
+	if len(keys) > 0 {
+		deleted += int(RDB.Unlink(ctx, keys...).Val())
+	}
+	return deleted, iter.Err()
+}
+
+// RecordCacheHit 记录一次读取是否命中
+func RecordCacheHit(ctx context.Context, namespace string, hit bool) {
+	field := "miss"
+	if hit {
+		field = "hit"
+	}
+	RDB.HIncrBy(ctx, fmt.Sprintf(KeyCacheGenStats, namespace), field, 1)
+}
+
+type GenerationInfo struct {
+	ID        int64 `json:"id"`
+	CreatedAt int64 `json:"createdAt"`
+	Keys      int   `json:"keys"`
+	Active    bool  `json:"active"`
+}
+
+type GenerationStats struct {
+	Namespace   string            `json:"namespace"`
+	Active      int64             `json:"active"`
+	ActivatedAt int64             `json:"activatedAt"`
+	Hits        int64             `json:"hits"`
+	Misses      int64             `json:"misses"`
+	Generations []*GenerationInfo `json:"generations"`
+}
+
+// Stats 缓存各代的key数量和命中情况
+func Stats(ctx context.Context, namespace string) (*GenerationStats, error) {
+	active, err := RDB.HGetAll(ctx, fmt.Sprintf(KeyCacheGenActive, namespace)).Result()
+	if err != nil {
+		return nil, err
+	}
+	counter, err := RDB.HGetAll(ctx, fmt.Sprintf(KeyCacheGenStats, namespace)).Result()
+	if err != nil {
+		return nil, err
+	}
+	stats := &GenerationStats{
+		Namespace:   namespace,
+		Active:      cast.ToInt64(active["id"]),
+		ActivatedAt: cast.ToInt64(active["activated"]),
+		Hits:        cast.ToInt64(counter["hit"]),
+		Misses:      cast.ToInt64(counter["miss"]),
+		Generations: make([]*GenerationInfo, 0),
+	}
+
+	gens, err := RDB.ZRevRangeWithScores(ctx, fmt.Sprintf(KeyCacheGenList, namespace), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, z := range gens {
+		g := &Generation{Namespace: namespace, ID: cast.ToInt64(z.Member)}
+		info := &GenerationInfo{
+			ID:        g.ID,
+			CreatedAt: int64(z.Score),
+			Active:    g.ID == stats.Active,
+		}
+		iter := RDB.Scan(ctx, 0, g.Key("*"), 1000).Iterator()
+		for iter.Next(ctx) {
+			info.Keys++
+		}
+		if err = iter.Err(); err != nil {
+			return nil, err
+		}
+		stats.Generations = append(stats.Generations, info)
+	}
+	return stats, nil
+}
@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"testing"
+)
+
+func TestGenerationKey(t *testing.T) {
+	cases := []struct {
+		g    *Generation
+		key  string
+		want string
+	}{
+		// 还没有切换过代时使用原来的key
+		{&Generation{Namespace: CacheNamespaceSuit, ID: 0}, KeyCacheHeroEquip, "cache:hero_equip"},
+		{&Generation{Namespace: CacheNamespaceSuit, ID: 3}, KeyCacheHeroEquip, "gen:suit:3:cache:hero_equip"},
+		{&Generation{Namespace: CacheNamespaceSuitHero, ID: 12}, fmt.Sprintf(KeyCacheEquipHeroSuit, 0, "3031"), "gen:suit_hero:12:cache:equip_hero:0:3031"},
+		{&Generation{Namespace: CacheNamespaceSuitHero, ID: 12}, "*", "gen:suit_hero:12:*"},
+	}
+	for _, c := range cases {
+		if got := c.g.Key(c.key); got != c.want {
+			t.Errorf("Key(%q) = %s, want %s", c.key, got, c.want)
+		}
+	}
+}
+
+func TestInvalidGeneration(t *testing.T) {
+	g := &Generation{Namespace: CacheNamespaceSuit}
+	if _, err := g.Activate(context.Background()); err == nil {
+		t.Errorf("Activate should fail for generation 0")
+	}
+	if _, err := g.Discard(context.Background()); err == nil {
+		t.Errorf("Discard should fail for generation 0")
+	}
+}
+
+func TestLegacyKeyPatterns(t *testing.T) {
+	cases := []struct {
+		namespace string
+		key       string
+		want      bool
+	}{
+		{CacheNamespaceSuit, fmt.Sprintf(KeyCacheEquip, "11", "0", "3031"), true},
+		{CacheNamespaceSuit, fmt.Sprintf(KeyCacheRune, "11", "0", "8005"), true},
+		{CacheNamespaceSuit, KeyCacheHeroEquip, true},
+		{CacheNamespaceSuitHero, fmt.Sprintf(KeyCacheSkillHeroSuit, 1, "4"), true},
+		{CacheNamespaceSuitHero, fmt.Sprintf(KeyCacheSuitHeroGames, "cache:rune_hero:0:8005"), true},
+		// 其他缓存和按代写入的key不能被删除
+		{CacheNamespaceSuit, fmt.Sprintf(KeyCacheEquipHeroSuit, 0, "3031"), false},
+		{CacheNamespaceSuit, fmt.Sprintf(KeyCacheVersionList, 1), false},
+		{CacheNamespaceSuit, "gen:suit:3:cache:hero_equip", false},
+		{CacheNamespaceSuitHero, fmt.Sprintf(KeyCacheTierList, 0, "14.1", "mid", 0, "x"), false},
+	}
+	for _, c := range cases {
+		matched := false
+		for _, pattern := range legacyKeyPatterns[c.namespace] {
+			// redis 的 glob 与 path.Match 在这些简单的 pattern 上一致
+			if ok, _ := path.Match(pattern, c.key); ok {
+				matched = true
+			}
+		}
+		if matched != c.want {
+			t.Errorf("%s legacy patterns match %q = %v, want %v", c.namespace, c.key, matched, c.want)
+		}
+	}
+}